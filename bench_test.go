@@ -0,0 +1,57 @@
+package htmldump_test
+
+import (
+	"io"
+	"strconv"
+	"testing"
+
+	"github.com/oslyak/htmldump"
+)
+
+type benchRow struct {
+	ID    int
+	Name  string
+	Score float64
+	Tags  []string
+}
+
+func benchRows(count int) []benchRow {
+	rows := make([]benchRow, count)
+	for idx := range rows {
+		rows[idx] = benchRow{ID: idx, Name: `row ` + strconv.Itoa(idx), Score: float64(idx) / 3}
+	}
+
+	return rows
+}
+
+func BenchmarkDumpSlice100k(b *testing.B) {
+	rows := benchRows(100_000)
+	dumper := htmldump.NewDumper(htmldump.WithMaxRows(0))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for range b.N {
+		if err := dumper.Dump(io.Discard, rows); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDumpMap100k(b *testing.B) {
+	rows := make(map[int]benchRow, 100_000)
+	for idx, row := range benchRows(100_000) {
+		rows[idx] = row
+	}
+
+	dumper := htmldump.NewDumper(htmldump.WithMaxRows(0))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for range b.N {
+		if err := dumper.Dump(io.Discard, rows); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
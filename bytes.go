@@ -0,0 +1,124 @@
+package htmldump
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// BytesView selects how byte slices and arrays, such as []byte,
+// json.RawMessage or [16]byte, are shown.
+type BytesView int
+
+const (
+	// BytesAuto shows bytes as text when they are printable UTF-8, as a hex dump otherwise.
+	BytesAuto BytesView = iota
+	// BytesHexDump shows bytes as a hex dump of offsets, hex values and ASCII.
+	BytesHexDump
+	// BytesText shows bytes as a UTF-8 string.
+	BytesText
+	// BytesBase64 shows bytes encoded with standard base64.
+	BytesBase64
+)
+
+// WithBytesView sets how byte slices and arrays are shown, BytesAuto by default.
+func WithBytesView(view BytesView) Option {
+	return func(dumper *Dumper) {
+		dumper.bytesView = view
+	}
+}
+
+// isBytes reports whether values of the type, or of the type it points to,
+// are byte slices or arrays shown as bytes. Leaf types, such as net.IP with
+// its String method, keep their own formatting.
+func (dumper *Dumper) isBytes(valueType reflect.Type) bool {
+	return dumper.typePlan(valueType).bytes
+}
+
+// bytesToHTML generates a one-row HTML table for a byte slice or array.
+func bytesToHTML(doc *htmlDocument, reflectedBytes reflect.Value) error {
+	caption := getFieldTypeName(reflectedBytes.Type())
+	if value := reflect.Indirect(reflectedBytes); value.IsValid() {
+		caption = fmt.Sprintf(`%s (length: %d)`, caption, value.Len())
+	}
+
+	table := doc.newTable()
+	table.Caption(caption).
+		scalarBody(reflectedBytes).
+		write(doc)
+
+	return nil
+}
+
+// bytesCell returns the cell showing the bytes in the view of the Dumper.
+func (dumper *Dumper) bytesCell(value reflect.Value) cellT {
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return cellT{value: NULL}
+		}
+
+		value = value.Elem()
+	}
+
+	if value.Kind() == reflect.Slice && value.IsNil() {
+		return cellT{value: NULL}
+	}
+
+	data := make([]byte, value.Len())
+	reflect.Copy(reflect.ValueOf(data), value)
+
+	view := dumper.bytesView
+	if view == BytesAuto {
+		view = BytesHexDump
+		if isPrintable(data) {
+			view = BytesText
+		}
+	}
+
+	switch view {
+	case BytesText:
+		return cellT{value: string(data)}
+	case BytesBase64:
+		return cellT{value: base64.StdEncoding.EncodeToString(data)}
+	default:
+		dump := dumper.hexDump(data)
+
+		return cellT{value: `<pre class="hexdump">` + escapeText(dump) + `</pre>`, html: true, text: dump}
+	}
+}
+
+// hexDump returns the hex dump of the bytes within the limit.
+func (dumper *Dumper) hexDump(data []byte) string {
+	more := 0
+	if limit := dumper.limits.MaxHexDump; limit > 0 && len(data) > limit {
+		more = len(data) - limit
+		data = data[:limit]
+	}
+
+	dump := strings.TrimSuffix(hex.Dump(data), "\n")
+	if more > 0 {
+		dump += fmt.Sprintf("\n… %s more bytes", formatCount(more))
+	}
+
+	return dump
+}
+
+// isPrintable reports whether the bytes are UTF-8 text without control
+// characters other than line breaks and tabs.
+func isPrintable(data []byte) bool {
+	if !utf8.Valid(data) {
+		return false
+	}
+
+	for _, char := range string(data) {
+		if !unicode.IsPrint(char) && !unicode.IsSpace(char) {
+			return false
+		}
+	}
+
+	return true
+}
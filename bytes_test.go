@@ -0,0 +1,59 @@
+package htmldump_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net"
+	"testing"
+
+	"github.com/oslyak/htmldump"
+
+	"github.com/stretchr/testify/require"
+)
+
+type packet struct {
+	Payload []byte
+	Raw     json.RawMessage
+	Digest  [4]byte
+	Address net.IP
+	Empty   []byte
+	secret  []byte
+}
+
+func TestDumpBytes(t *testing.T) {
+	t.Parallel()
+
+	input := packet{
+		Payload: []byte(`hello <world>`),
+		Raw:     json.RawMessage(`{"a":1}`),
+		Digest:  [4]byte{0xde, 0xad, 0xbe, 0xef},
+		Address: net.IPv4(10, 0, 0, 1),
+		secret:  []byte{0, 1, 2},
+	}
+
+	buffer := bytes.NewBuffer([]byte{})
+	err := htmldump.ToHTML(buffer, input, []byte{0x00, 0x41})
+	require.NoError(t, err)
+
+	tables := removeStyle(t, extractHTMLTables(t, buffer.String()))
+
+	require.Contains(t, tables, `<tr><td>Payload</td><td>[]uint8</td><td>hello &lt;world&gt;</td></tr>`)
+	require.Contains(t, tables, `<td>{&#34;a&#34;:1}</td></tr>`)
+	require.Contains(t, tables, `<tr><td>Digest</td><td>[4]uint8</td><td><pre >00000000  de ad be ef                                       |....|</pre></td></tr>`)
+	require.Contains(t, tables, `<tr><td>Address</td><td>IP</td><td>10.0.0.1</td></tr>`)
+	require.Contains(t, tables, `<tr><td>Empty</td><td>[]uint8</td><td>NULL</td></tr>`)
+	require.Contains(t, tables, `<tr><td>secret</td><td>[]uint8</td><td><pre >00000000  00 01 02 `)
+	require.Contains(t, tables, `<caption>[]uint8 (length: 2)</caption><thead></thead><tbody><tr><td>Value</td><td><pre >00000000  00 41 `)
+
+	buffer = bytes.NewBuffer([]byte{})
+	err = htmldump.NewDumper(htmldump.WithBytesView(htmldump.BytesBase64)).Dump(buffer, input)
+	require.NoError(t, err)
+
+	require.Contains(t, removeStyle(t, extractHTMLTable(t, buffer.String())), `<tr><td>Payload</td><td>[]uint8</td><td>aGVsbG8gPHdvcmxkPg==</td></tr>`)
+
+	buffer = bytes.NewBuffer([]byte{})
+	err = htmldump.NewDumper(htmldump.WithBytesView(htmldump.BytesHexDump), htmldump.WithMaxHexDump(16)).Dump(buffer, make([]byte, 40))
+	require.NoError(t, err)
+
+	require.Contains(t, buffer.String(), "|................|\n… 24 more bytes</pre>")
+}
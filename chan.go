@@ -0,0 +1,36 @@
+package htmldump
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// chanToHTML generates HTML table for a channel, with its element type, length and capacity.
+func chanToHTML(doc *htmlDocument, reflectedChan reflect.Value) error {
+	if reflectedChan.Kind() != reflect.Chan {
+		return fmt.Errorf(`[chanToHTML] only accepts chan, got %s`, reflectedChan.Kind())
+	}
+
+	table := doc.newTable()
+	table.Caption(reflectedChan.Type().String()).
+		chanBody(reflectedChan).
+		write(doc)
+
+	return nil
+}
+
+func (table *tableT) chanBody(reflectedChan reflect.Value) *tableT {
+	table.propertyRow(`Element type`, getFieldTypeName(reflectedChan.Type().Elem()))
+
+	if reflectedChan.IsNil() {
+		table.propertyRow(`Value`, NULL)
+
+		return table
+	}
+
+	table.propertyRow(`Length`, strconv.Itoa(reflectedChan.Len()))
+	table.propertyRow(`Capacity`, strconv.Itoa(reflectedChan.Cap()))
+
+	return table
+}
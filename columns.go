@@ -0,0 +1,289 @@
+package htmldump
+
+import (
+	"reflect"
+)
+
+// columnT is a column of a slice or map table showing a struct field, or a group
+// of columns for a struct field flattened into the fields of its own.
+type columnT struct {
+	// index leads from the struct holding the column to the field, it is longer
+	// than one for fields of inline structs.
+	index    []int
+	field    reflect.StructField
+	tag      fieldTagT
+	children []*columnT
+	// unflattened is set for struct fields shown as a single value,
+	// because the flattening stopped above them.
+	unflattened bool
+}
+
+// leaves returns the number of table columns the column spans.
+func (column *columnT) leaves() int {
+	if len(column.children) == 0 {
+		return 1
+	}
+
+	leaves := 0
+	for _, child := range column.children {
+		leaves += child.leaves()
+	}
+
+	return leaves
+}
+
+// depth returns the number of header rows the column and its children take.
+func (column *columnT) depth() int {
+	depth := 0
+	for _, child := range column.children {
+		depth = max(depth, child.depth())
+	}
+
+	return depth + 1
+}
+
+// structColumns returns the columns of the struct type, nested struct fields are
+// flattened down to the flatten depth. The path holds the struct types being
+// flattened, a recursive type is shown as a single value where it repeats.
+func (dumper *Dumper) structColumns(structType reflect.Type, level int, path map[reflect.Type]bool) []*columnT {
+	if structType.Kind() == reflect.Pointer {
+		structType = structType.Elem()
+	}
+
+	path[structType] = true
+	defer delete(path, structType)
+
+	var columns []*columnT
+
+	for idx := 0; idx < structType.NumField(); idx++ {
+		field := structType.Field(idx)
+		tag := parseFieldTag(field)
+
+		if tag.skip {
+			continue
+		}
+
+		column := &columnT{index: []int{idx}, field: field, tag: tag}
+
+		if !dumper.flattens(field.Type, tag, level, path) {
+			column.unflattened = dumper.expands(field.Type, tag)
+			columns = append(columns, column)
+
+			continue
+		}
+
+		if tag.inline {
+			for _, child := range dumper.structColumns(field.Type, level, path) {
+				child.index = append([]int{idx}, child.index...)
+				columns = append(columns, child)
+			}
+
+			continue
+		}
+
+		column.children = dumper.structColumns(field.Type, level+1, path)
+		if len(column.children) == 0 {
+			column.children = nil
+		}
+
+		columns = append(columns, column)
+	}
+
+	return columns
+}
+
+// flattens reports whether the struct field at the nesting level is flattened into columns.
+func (dumper *Dumper) flattens(fieldType reflect.Type, tag fieldTagT, level int, path map[reflect.Type]bool) bool {
+	if !dumper.expands(fieldType, tag) {
+		return false
+	}
+
+	if fieldType.Kind() == reflect.Pointer {
+		fieldType = fieldType.Elem()
+	}
+
+	return !path[fieldType] &&
+		(dumper.flattenDepth <= 0 || level < dumper.flattenDepth) &&
+		!dumper.limits.depthExceeded(level+1)
+}
+
+// headerRow adds the header of a slice or map table. The captions and types rows
+// hold the key column, the value type adds a column per field for structs,
+// and a header row per level of flattened struct fields.
+func (table *tableT) headerRow(valueType reflect.Type, captions, types *rowT) {
+	if valueType.Kind() == reflect.Pointer {
+		valueType = valueType.Elem()
+	}
+
+	if table.dumper.isExpandable(valueType) {
+		table.fields = table.dumper.structColumnsPlan(valueType)
+	}
+
+	if len(table.fields) == 0 {
+		column := len(types.cells)
+
+		captions.addCell(sortableCell(cellT{value: `value`}, column, valueType))
+		types.addCell(sortableCell(cellT{value: getFieldTypeName(valueType)}, column, valueType))
+
+		table.addHeaderRow(*captions)
+		table.addHeaderRow(*types)
+
+		table.columns = len(types.cells)
+
+		return
+	}
+
+	depth := 0
+	for _, column := range table.fields {
+		depth = max(depth, column.depth())
+	}
+
+	rows := make([]rowT, max(depth, 2))
+	last := len(rows) - 1
+
+	for idx := range captions.cells {
+		captions.cells[idx].rowspan = last
+	}
+
+	rows[0] = *captions
+	rows[last].cells = append(rows[last].cells, types.cells...)
+
+	next := len(types.cells)
+	for _, column := range table.fields {
+		columnHeader(rows, column, 0, &next)
+	}
+
+	table.columns = len(types.cells)
+	for _, column := range table.fields {
+		table.columns += column.leaves()
+	}
+
+	for _, row := range rows {
+		table.addHeaderRow(row)
+	}
+}
+
+// columnHeader adds the header cells of the column at the nesting level.
+// Top level fields show their name and type in separate rows, like the key
+// column, nested ones show both in a single cell. Leaves sort by the next
+// column number, groups spanning several columns do not sort.
+func columnHeader(rows []rowT, column *columnT, level int, next *int) {
+	last := len(rows) - 1
+
+	switch {
+	case len(column.children) > 0:
+		rows[level].addCell(cellT{
+			value:   structFieldCaption(column.field, column.tag),
+			title:   column.tag.label(column.field),
+			colspan: column.leaves(),
+		})
+
+		for _, child := range column.children {
+			columnHeader(rows, child, level+1, next)
+		}
+
+		return
+	case level == 0:
+		fieldType := column.field.Type
+
+		rows[0].addCell(sortableCell(cellT{value: column.tag.label(column.field), rowspan: last}, *next, fieldType))
+		rows[last].addCell(sortableCell(cellT{value: getFieldTypeName(fieldType)}, *next, fieldType))
+	default:
+		rows[level].addCell(sortableCell(cellT{
+			value:   structFieldCaption(column.field, column.tag),
+			rowspan: len(rows) - level,
+		}, *next, column.field.Type))
+	}
+
+	*next++
+}
+
+// structRow adds the cells of a struct element of a slice or map table to the row.
+func (table *tableT) structRow(row *rowT, item reflect.Value) {
+	if !item.CanAddr() {
+		addressable := reflect.New(item.Type()).Elem()
+		addressable.Set(item)
+		item = addressable
+	}
+
+	table.columnCells(row, item, table.fields)
+}
+
+// columnCells adds a cell per column of the struct value to the row.
+func (table *tableT) columnCells(row *rowT, structValue reflect.Value, columns []*columnT) {
+	for _, column := range columns {
+		field, ok := columnValue(structValue, column.index)
+
+		switch {
+		case !ok:
+			row.addCell(cellT{value: NULL, colspan: column.leaves()})
+		case len(column.children) > 0:
+			table.groupCells(row, field, column)
+		case column.unflattened:
+			row.addCell(table.unflattenedCell(field))
+		default:
+			row.addCell(table.valueCell(field, column.tag, 0))
+		}
+	}
+}
+
+// groupCells adds the cells of a flattened struct field to the row.
+func (table *tableT) groupCells(row *rowT, field reflect.Value, column *columnT) {
+	colspan := column.leaves()
+
+	leave, seen, ok := table.enter(field, visitT{label: `↺ shown in an outer table`})
+	if ok {
+		row.addCell(cellT{value: seen.label, link: seen.anchor, colspan: colspan})
+
+		return
+	}
+	defer leave()
+
+	if field.Kind() == reflect.Pointer {
+		if field.IsNil() {
+			row.addCell(cellT{value: NULL, colspan: colspan})
+
+			return
+		}
+
+		field = field.Elem()
+	}
+
+	table.columnCells(row, field, column.children)
+}
+
+// unflattenedCell returns the cell for a struct field left out of flattening,
+// a link when the struct is already shown, as recursive types often are.
+func (table *tableT) unflattenedCell(field reflect.Value) cellT {
+	if seen, ok := table.seen(field); ok {
+		return cellT{value: seen.label, link: seen.anchor}
+	}
+
+	if field.Kind() == reflect.Pointer && !field.IsNil() {
+		if seen, ok := table.path[visitKey{address: field.Pointer(), valueType: field.Type()}]; ok {
+			return cellT{value: seen.label, link: seen.anchor}
+		}
+	}
+
+	return cellT{value: table.dumper.formatStruct(field)}
+}
+
+// columnValue returns the field the index leads to, following the pointers to
+// inline structs on the way. It reports false when one of them is nil.
+func columnValue(structValue reflect.Value, index []int) (reflect.Value, bool) {
+	value := structValue
+
+	for position, idx := range index {
+		if position > 0 && value.Kind() == reflect.Pointer {
+			if value.IsNil() {
+				return reflect.Value{}, false
+			}
+
+			value = value.Elem()
+		}
+
+		value = readableField(value, idx)
+	}
+
+	return value, true
+}
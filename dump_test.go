@@ -19,10 +19,23 @@ func extractHTMLTable(t *testing.T, doc string) string {
 	return table
 }
 
+func extractHTMLTables(t *testing.T, doc string) string {
+	t.Helper()
+
+	being := strings.Index(doc, `<table`)
+	end := strings.LastIndex(doc, `</table>`) + len(`</table>`)
+	tables := doc[being:end]
+
+	whiteSpaces := regexp.MustCompile(`>\s+<`)
+	tables = whiteSpaces.ReplaceAllString(tables, "><")
+
+	return tables
+}
+
 func removeStyle(t *testing.T, table string) string {
 	t.Helper()
 
-	style := regexp.MustCompile(`class="[^"]+"|style="[^"]+"`)
+	style := regexp.MustCompile(`class="[^"]+"|style="[^"]+"|id="[^"]+"`)
 	table = style.ReplaceAllString(table, ``)
 
 	td := regexp.MustCompile(`<td\s+>`)
@@ -31,6 +44,9 @@ func removeStyle(t *testing.T, table string) string {
 	th := regexp.MustCompile(`<th\s+>`)
 	table = th.ReplaceAllString(table, `<th>`)
 
+	tr := regexp.MustCompile(`<tr\s+>`)
+	table = tr.ReplaceAllString(table, `<tr>`)
+
 	// tableRE := regexp.MustCompile(`<table\s+>`)
 	// table = tableRE.ReplaceAllString(table, `<table>`)
 
@@ -0,0 +1,249 @@
+package htmldump
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultTimeFormat is the layout used for time.Time and sql.NullTime values.
+	DefaultTimeFormat = `02.01.2006 15:04:05`
+	// DefaultReloadInterval is how often the generated page reloads itself.
+	DefaultReloadInterval = 2 * time.Second
+	// MinReloadInterval is the shortest interval the page reloads or polls at,
+	// shorter ones would keep the browser busy reloading.
+	MinReloadInterval = 100 * time.Millisecond
+)
+
+// Dumper renders inputs to HTML using a reusable configuration.
+// Build it once with NewDumper and share it across a codebase,
+// it holds no per-dump state and is safe for concurrent use.
+type Dumper struct {
+	limits         Limits
+	timeFormat     string
+	reloadInterval time.Duration
+	reloadOnChange bool
+	styles         []string
+	formatters     formattersT
+	leafTypes      leafTypesT
+	mapSort        mapSortT
+	collapseNested bool
+	flattenDepth   int
+	bytesView      BytesView
+	pageSize       int
+	sortFilter     bool
+	treeView       bool
+	theme          *Theme
+	lightTheme     *Theme
+	darkTheme      *Theme
+	textWidth      int
+	textOverflow   TextOverflow
+	ansiColors     bool
+	plans          plansT
+}
+
+// sharedDumper is the Dumper of ToHTML, ToHTMLAndOpen, ToMarkdown and ToText,
+// kept between calls with the plans of types.
+var sharedDumper struct {
+	mutex  sync.Mutex
+	dumper *Dumper
+}
+
+// defaultDumper returns the Dumper with the default options for the shortcuts.
+// The shared one is replaced when DefaultLimits changed, as plans depend on them.
+func defaultDumper() *Dumper {
+	sharedDumper.mutex.Lock()
+	defer sharedDumper.mutex.Unlock()
+
+	if sharedDumper.dumper == nil || sharedDumper.dumper.limits != DefaultLimits {
+		sharedDumper.dumper = NewDumper()
+	}
+
+	return sharedDumper.dumper
+}
+
+// Option configures a Dumper.
+type Option func(*Dumper)
+
+// NewDumper creates a Dumper with the default configuration changed by the options.
+func NewDumper(options ...Option) *Dumper {
+	dumper := &Dumper{
+		limits:         DefaultLimits,
+		timeFormat:     DefaultTimeFormat,
+		reloadInterval: DefaultReloadInterval,
+	}
+
+	for _, option := range options {
+		option(dumper)
+	}
+
+	return dumper
+}
+
+// WithLimits replaces all the limits at once.
+func WithLimits(limits Limits) Option {
+	return func(dumper *Dumper) {
+		dumper.limits = limits
+	}
+}
+
+// WithMaxDepth sets the number of nested struct levels expanded, zero means no limit.
+func WithMaxDepth(depth int) Option {
+	return func(dumper *Dumper) {
+		dumper.limits.MaxDepth = depth
+	}
+}
+
+// WithMaxRows sets the number of body rows per table, zero means no limit.
+func WithMaxRows(rows int) Option {
+	return func(dumper *Dumper) {
+		dumper.limits.MaxRows = rows
+	}
+}
+
+// WithMaxCellLength sets the number of characters per cell, zero means no limit.
+func WithMaxCellLength(length int) Option {
+	return func(dumper *Dumper) {
+		dumper.limits.MaxCellLength = length
+	}
+}
+
+// WithMaxHexDump sets the number of bytes shown in a hex dump, zero means no limit.
+func WithMaxHexDump(bytes int) Option {
+	return func(dumper *Dumper) {
+		dumper.limits.MaxHexDump = bytes
+	}
+}
+
+// WithTimeFormat sets the layout used for time.Time and sql.NullTime values.
+func WithTimeFormat(layout string) Option {
+	return func(dumper *Dumper) {
+		dumper.timeFormat = layout
+	}
+}
+
+// WithAutoReload sets how often the generated page reloads itself,
+// zero or a negative interval disables reloading. Intervals shorter than
+// MinReloadInterval are raised to it.
+// See WithChangeAwareReload to reload only when the content changed.
+func WithAutoReload(interval time.Duration) Option {
+	return func(dumper *Dumper) {
+		dumper.reloadInterval = interval
+		dumper.reloadOnChange = false
+	}
+}
+
+// WithStyle appends CSS to the built-in stylesheet, so it can override any of its rules.
+func WithStyle(css string) Option {
+	return func(dumper *Dumper) {
+		dumper.styles = append(dumper.styles, css)
+	}
+}
+
+// WithCollapsedNested shows the slices and maps nested in other values as
+// collapsed sub-tables, expanded on click. They are shown expanded by default.
+func WithCollapsedNested() Option {
+	return func(dumper *Dumper) {
+		dumper.collapseNested = true
+	}
+}
+
+// WithFlattenDepth sets how many levels of nested struct fields are flattened
+// into columns of slice and map tables, deeper structs are shown as a single
+// value. Zero, the default, flattens down to the depth limit.
+func WithFlattenDepth(levels int) Option {
+	return func(dumper *Dumper) {
+		dumper.flattenDepth = levels
+	}
+}
+
+// Dump writes the inputs to the writer as an HTML document.
+// The inputs can be values of any kind, or pointers to them.
+// The document is streamed, each table is written as soon as it is complete,
+// so on error the writer may hold the beginning of the document.
+func (dumper *Dumper) Dump(writer io.Writer, inputs ...interface{}) error {
+	if len(inputs) == 0 {
+		return errors.New(`[Dump] requires at least one inputs argument`)
+	}
+
+	return dumper.dumpDocument(newHTMLDocument(writer, dumper), inputs)
+}
+
+// dumpDocument writes the inputs to the document and completes it.
+func (dumper *Dumper) dumpDocument(doc *htmlDocument, inputs []interface{}) error {
+	for _, input := range inputs {
+		err := dumper.dumpValue(doc, input)
+		if err != nil {
+			return err
+		}
+	}
+
+	doc.end()
+
+	return doc.save()
+}
+
+// DumpFile writes the inputs to an HTML file at the path, replacing the file if it exists.
+func (dumper *Dumper) DumpFile(path string, inputs ...interface{}) error {
+	absolutePath, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("[DumpFile] getting absolute path to %s error: %w", path, err)
+	}
+
+	file, err := os.Create(absolutePath)
+	if err != nil {
+		return fmt.Errorf("[DumpFile] file %s creating error: %w", absolutePath, err)
+	}
+
+	err = dumper.Dump(file, inputs...)
+	if err != nil {
+		file.Close()
+
+		return err
+	}
+
+	err = file.Close()
+	if err != nil {
+		return fmt.Errorf("[DumpFile] file %s closing error: %w", absolutePath, err)
+	}
+
+	return nil
+}
+
+// dumpValue picks the table generator for the input type.
+func (dumper *Dumper) dumpValue(doc *htmlDocument, input interface{}) error {
+	reflectedValue := reflect.ValueOf(input)
+
+	// Nil and nil pointers have nothing to show but their type.
+	if !reflectedValue.IsValid() || reflectedValue.Kind() == reflect.Pointer && reflectedValue.IsNil() {
+		return scalarToHTML(doc, reflectedValue)
+	}
+
+	switch {
+	case dumper.isBytes(reflectedValue.Type()):
+		return bytesToHTML(doc, reflectedValue)
+	case isMapOrPointerToMap(reflectedValue):
+		return mapToHTML(doc, reflectedValue)
+	case isPointerToSliceOrSlice(reflectedValue):
+		return sliceToHTML(doc, reflectedValue)
+	case isStructOrPointerToStruct(reflectedValue.Type()):
+		return structToHTML(doc, input)
+	case isStringOrPointerToString(reflectedValue.Type()):
+		return stringToHTML(doc, reflect.Indirect(reflectedValue))
+	}
+
+	switch reflect.Indirect(reflectedValue).Kind() {
+	case reflect.Func:
+		return funcToHTML(doc, reflect.Indirect(reflectedValue))
+	case reflect.Chan:
+		return chanToHTML(doc, reflect.Indirect(reflectedValue))
+	default:
+		return scalarToHTML(doc, reflectedValue)
+	}
+}
@@ -0,0 +1,177 @@
+package htmldump_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/oslyak/htmldump"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDumper(t *testing.T) {
+	t.Parallel()
+
+	type event struct {
+		Name string
+		At   time.Time
+	}
+
+	dumper := htmldump.NewDumper(
+		htmldump.WithTimeFormat(time.DateOnly),
+		htmldump.WithAutoReload(0),
+		htmldump.WithMaxRows(2),
+		htmldump.WithStyle(`.styled-table { font-size: 1.2em; }`),
+	)
+
+	input := event{Name: `release`, At: time.Date(2024, 5, 17, 10, 0, 0, 0, time.UTC)}
+
+	buffer := bytes.NewBuffer([]byte{})
+	err := dumper.Dump(buffer, input, []int{1, 2, 3})
+	require.NoError(t, err)
+
+	doc := buffer.String()
+
+	require.NotContains(t, doc, `location.reload()`)
+	require.Contains(t, doc, `.styled-table { font-size: 1.2em; }`)
+	require.Contains(t, doc, `>2024-05-17</td>`)
+	require.Contains(t, doc, `… 1 more elements</td>`)
+
+	buffer = bytes.NewBuffer([]byte{})
+	err = htmldump.NewDumper(htmldump.WithAutoReload(5*time.Second)).Dump(buffer, input)
+	require.NoError(t, err)
+
+	require.Contains(t, buffer.String(), `}, 5000);`)
+
+	buffer = bytes.NewBuffer([]byte{})
+	err = htmldump.NewDumper(htmldump.WithChangeAwareReload(3*time.Second)).Dump(buffer, input)
+	require.NoError(t, err)
+
+	require.Contains(t, buffer.String(), `fetch(location.href, {cache: 'no-store'})`)
+	require.Contains(t, buffer.String(), `poll(current); }, 3000);`)
+	require.NotContains(t, buffer.String(), `setInterval(`)
+	require.Contains(t, buffer.String(), `if (location.protocol === 'file:') {
+          setTimeout(function () {
+            save();
+            location.reload();
+          }, 3000);`)
+
+	buffer = bytes.NewBuffer([]byte{})
+	err = htmldump.NewDumper(htmldump.WithoutAutoReload()).Dump(buffer, input)
+	require.NoError(t, err)
+
+	require.NotContains(t, buffer.String(), `<script>`)
+
+	buffer = bytes.NewBuffer([]byte{})
+	err = htmldump.NewDumper(htmldump.WithChangeAwareReload(time.Second), htmldump.WithAutoReload(time.Microsecond)).Dump(buffer, input)
+	require.NoError(t, err)
+
+	require.Contains(t, buffer.String(), `}, 100);`)
+	require.NotContains(t, buffer.String(), `fetch(`)
+
+	path := filepath.Join(t.TempDir(), `dump.html`)
+	err = dumper.DumpFile(path, input)
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Contains(t, string(content), `<caption>struct event</caption>`)
+
+	err = dumper.Dump(buffer)
+	require.Error(t, err)
+}
+
+// recordingWriter remembers the size of every write, failing once it got limit bytes.
+type recordingWriter struct {
+	writes []int
+	total  int
+	limit  int
+}
+
+func (writer *recordingWriter) Write(data []byte) (int, error) {
+	if writer.limit > 0 && writer.total >= writer.limit {
+		return 0, errors.New(`disk full`)
+	}
+
+	writer.writes = append(writer.writes, len(data))
+	writer.total += len(data)
+
+	return len(data), nil
+}
+
+func TestDumpStreaming(t *testing.T) {
+	t.Parallel()
+
+	rows := make([]int, 20_000)
+
+	writer := &recordingWriter{}
+	err := htmldump.NewDumper(htmldump.WithMaxRows(0)).Dump(writer, rows)
+	require.NoError(t, err)
+
+	// The head is flushed on its own, then the table in buffer sized chunks.
+	require.Greater(t, len(writer.writes), 10)
+	require.Less(t, writer.writes[0], 16*1024)
+
+	writer = &recordingWriter{limit: 100_000}
+	err = htmldump.NewDumper(htmldump.WithMaxRows(0)).Dump(writer, rows)
+	require.ErrorContains(t, err, `disk full`)
+}
+
+func TestDumpStreamingRows(t *testing.T) {
+	t.Parallel()
+
+	type probe int
+
+	rows := make([]probe, 20_000)
+	rows[len(rows)-1] = 1
+
+	writer := &recordingWriter{}
+	written := 0
+
+	dumper := htmldump.NewDumper(
+		htmldump.WithMaxRows(0),
+		htmldump.WithFormatter(func(value probe) string {
+			if value == 1 {
+				written = writer.total
+			}
+
+			return strconv.Itoa(int(value))
+		}),
+	)
+
+	err := dumper.Dump(writer, rows)
+	require.NoError(t, err)
+
+	// The rows before the last one are written before the table is complete.
+	require.Greater(t, written, writer.writes[0]+100_000)
+}
+
+// TestShortcutsFollowDefaultLimits is not parallel, it changes DefaultLimits.
+func TestShortcutsFollowDefaultLimits(t *testing.T) {
+	saved := htmldump.DefaultLimits
+	defer func() { htmldump.DefaultLimits = saved }()
+
+	rows := []int{1, 2, 3}
+
+	buffer := bytes.NewBuffer([]byte{})
+	err := htmldump.ToHTML(buffer, rows)
+	require.NoError(t, err)
+	require.NotContains(t, buffer.String(), `more elements`)
+
+	htmldump.DefaultLimits.MaxRows = 2
+
+	buffer = bytes.NewBuffer([]byte{})
+	err = htmldump.ToHTML(buffer, rows)
+	require.NoError(t, err)
+	require.Contains(t, buffer.String(), `… 1 more elements</td>`)
+
+	buffer = bytes.NewBuffer([]byte{})
+	err = htmldump.ToMarkdown(buffer, rows)
+	require.NoError(t, err)
+	require.Contains(t, buffer.String(), `… 1 more elements`)
+}
@@ -0,0 +1,39 @@
+package htmldump
+
+import (
+	"html"
+	"reflect"
+)
+
+// HTML is a trusted HTML fragment. Values of this type are written into the
+// document as-is, every other value, caption and type name is escaped.
+// Use it only for markup you produced yourself, never for user input.
+type HTML string
+
+var trustedHTMLType = reflect.TypeOf(HTML(``))
+
+// escapeText escapes a string for use as element text content.
+func escapeText(text string) string {
+	return html.EscapeString(text)
+}
+
+// escapeAttr escapes a string for use inside a double-quoted attribute value.
+// html.EscapeString already covers quotes, the separate name keeps the
+// call sites explicit about the context they write to.
+func escapeAttr(value string) string {
+	return html.EscapeString(value)
+}
+
+// isTrustedHTML reports whether the value is an HTML fragment or a pointer to it.
+func isTrustedHTML(value reflect.Value) bool {
+	if !value.IsValid() {
+		return false
+	}
+
+	valueType := value.Type()
+	if valueType.Kind() == reflect.Pointer {
+		valueType = valueType.Elem()
+	}
+
+	return valueType == trustedHTMLType
+}
@@ -0,0 +1,46 @@
+package htmldump_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/oslyak/htmldump"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEscapeValues(t *testing.T) {
+	t.Parallel()
+
+	type comment struct {
+		Author string
+		Body   string
+		Badge  htmldump.HTML
+	}
+
+	input := comment{
+		Author: `<script>alert(1)</script>`,
+		Body:   `</td></tr>"quoted" & 'single'`,
+		Badge:  `<b>trusted</b>`,
+	}
+
+	buffer := bytes.NewBuffer([]byte{})
+	err := htmldump.ToHTML(buffer, input, map[string]string{`<key>`: `<value>`}, `<i>raw</i>`)
+	require.NoError(t, err)
+
+	doc := buffer.String()
+
+	require.NotContains(t, doc, `<script>alert`)
+	require.Contains(t, doc, `&lt;script&gt;alert(1)&lt;/script&gt;`)
+	require.Contains(t, doc, `&lt;/td&gt;&lt;/tr&gt;&#34;quoted&#34; &amp; &#39;single&#39;`)
+	require.Contains(t, doc, `<b>trusted</b>`)
+	require.Contains(t, doc, `&lt;key&gt;`)
+	require.Contains(t, doc, `&lt;value&gt;`)
+	require.Contains(t, doc, `&lt;i&gt;raw&lt;/i&gt;`)
+
+	buffer = bytes.NewBuffer([]byte{})
+	err = htmldump.ToHTML(buffer, htmldump.HTML(`<i>raw</i>`))
+	require.NoError(t, err)
+
+	require.Contains(t, buffer.String(), `<i>raw</i>`)
+}
@@ -0,0 +1,139 @@
+package htmldump
+
+import (
+	"reflect"
+	"sync"
+)
+
+// FormatFunc formats a value into the text of a table cell.
+type FormatFunc func(value reflect.Value) string
+
+// formatterT binds a format function to a type. Interface types match
+// every value implementing them.
+type formatterT struct {
+	valueType reflect.Type
+	format    FormatFunc
+}
+
+// formattersT is a registry of formatters. Exact types are looked up first,
+// then interfaces in the order they were registered.
+type formattersT struct {
+	mutex      sync.RWMutex
+	exact      map[reflect.Type]FormatFunc
+	interfaces []formatterT
+}
+
+// globalFormatters holds the formatters registered with RegisterFormatter,
+// shared by every Dumper.
+var globalFormatters formattersT
+
+// RegisterFormatter registers a formatter for the type T for every Dumper.
+// T may be an interface, such as fmt.Stringer, error or encoding.TextMarshaler,
+// then it formats every value implementing it. Formatters of a concrete
+// type take precedence over interface ones, and formatters set on a Dumper
+// with WithFormatter take precedence over the registered ones.
+func RegisterFormatter[T any](format func(T) string) {
+	globalFormatters.add(typeOf[T](), formatFuncOf(format))
+}
+
+// RegisterTypeFormatter is RegisterFormatter for a type known only at run time.
+func RegisterTypeFormatter(valueType reflect.Type, format FormatFunc) {
+	globalFormatters.add(valueType, format)
+}
+
+// WithFormatter sets a formatter for the type T on the Dumper only.
+func WithFormatter[T any](format func(T) string) Option {
+	return func(dumper *Dumper) {
+		dumper.formatters.add(typeOf[T](), formatFuncOf(format))
+	}
+}
+
+// WithTypeFormatter is WithFormatter for a type known only at run time.
+func WithTypeFormatter(valueType reflect.Type, format FormatFunc) Option {
+	return func(dumper *Dumper) {
+		dumper.formatters.add(valueType, format)
+	}
+}
+
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+func formatFuncOf[T any](format func(T) string) FormatFunc {
+	return func(value reflect.Value) string {
+		return format(value.Interface().(T))
+	}
+}
+
+func (formatters *formattersT) add(valueType reflect.Type, format FormatFunc) {
+	formatters.mutex.Lock()
+	defer formatters.mutex.Unlock()
+
+	if valueType.Kind() == reflect.Interface {
+		formatters.interfaces = append(formatters.interfaces, formatterT{valueType: valueType, format: format})
+		registryVersion.Add(1)
+
+		return
+	}
+
+	if formatters.exact == nil {
+		formatters.exact = make(map[reflect.Type]FormatFunc)
+	}
+
+	formatters.exact[valueType] = format
+
+	registryVersion.Add(1)
+}
+
+// find returns the formatter for values of the type.
+func (formatters *formattersT) find(valueType reflect.Type) (FormatFunc, bool) {
+	formatters.mutex.RLock()
+	defer formatters.mutex.RUnlock()
+
+	if format, ok := formatters.exact[valueType]; ok {
+		return format, true
+	}
+
+	for _, formatter := range formatters.interfaces {
+		if valueType.Implements(formatter.valueType) {
+			return formatter.format, true
+		}
+	}
+
+	return nil, false
+}
+
+// hasFormatter reports whether values of the type, or of the type it points
+// to, have a formatter.
+func (dumper *Dumper) hasFormatter(valueType reflect.Type) bool {
+	return dumper.typePlan(valueType).formatted
+}
+
+// customFormat formats the value with its formatter, if there is one.
+// Pointers and interfaces are followed, nil pointers are left to the caller.
+func (dumper *Dumper) customFormat(value reflect.Value) (string, bool) {
+	for value.IsValid() && value.CanInterface() {
+		if value.Kind() == reflect.Interface {
+			value = value.Elem()
+
+			continue
+		}
+
+		if value.Kind() == reflect.Pointer && value.IsNil() {
+			break
+		}
+
+		plan := dumper.typePlan(value.Type())
+		if plan.format != nil {
+			return plan.format(value), true
+		}
+
+		if value.Kind() != reflect.Pointer || !plan.formatted {
+			break
+		}
+
+		value = value.Elem()
+	}
+
+	return ``, false
+}
@@ -0,0 +1,75 @@
+package htmldump_test
+
+import (
+	"bytes"
+	"encoding"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/oslyak/htmldump"
+
+	"github.com/stretchr/testify/require"
+)
+
+type money struct {
+	units int64
+	cents int64
+}
+
+type accountID [4]byte
+
+func (id accountID) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf(`acc-%x`, id[:])), nil
+}
+
+type balance struct {
+	Account accountID
+	Amount  money
+	Limit   *money
+	Error   error
+}
+
+func TestFormatters(t *testing.T) {
+	t.Parallel()
+
+	htmldump.RegisterFormatter(func(amount money) string {
+		return fmt.Sprintf(`$%d.%02d`, amount.units, amount.cents)
+	})
+
+	dumper := htmldump.NewDumper(
+		htmldump.WithFormatter(func(marshaler encoding.TextMarshaler) string {
+			text, _ := marshaler.MarshalText()
+			return string(text)
+		}),
+		htmldump.WithFormatter(func(err error) string {
+			return `error: ` + err.Error()
+		}),
+		htmldump.WithTypeFormatter(reflect.TypeOf(int64(0)), func(value reflect.Value) string {
+			return fmt.Sprintf(`#%d`, value.Int())
+		}),
+	)
+
+	input := balance{
+		Account: accountID{0xde, 0xad, 0xbe, 0xef},
+		Amount:  money{units: 12, cents: 5},
+		Limit:   &money{units: 100},
+		Error:   fmt.Errorf(`insufficient funds`),
+	}
+
+	buffer := bytes.NewBuffer([]byte{})
+	err := dumper.Dump(buffer, input, []balance{input}, map[int64]string{42: `answer`})
+	require.NoError(t, err)
+
+	doc := removeStyle(t, extractHTMLTable(t, buffer.String()))
+
+	require.Contains(t, doc, `<tr><td>Account</td><td>accountID</td><td>acc-deadbeef</td></tr>`)
+	require.Contains(t, doc, `<tr><td>Amount</td><td>money</td><td>$12.05</td></tr>`)
+	require.Contains(t, doc, `<tr><td>Limit</td><td>*money</td><td>$100.00</td></tr>`)
+	require.Contains(t, doc, `<tr><td>Error</td><td>error</td><td><span >*errorString</span>error: insufficient funds</td></tr>`)
+
+	doc = removeStyle(t, extractHTMLTables(t, buffer.String()))
+
+	require.Contains(t, doc, `<tr><td>0</td><td>acc-deadbeef</td><td>$12.05</td><td>$100.00</td><td><span >*errorString</span>error: insufficient funds</td></tr>`)
+	require.Contains(t, doc, `<tr><td>#42</td><td>answer</td></tr>`)
+}
@@ -0,0 +1,54 @@
+package htmldump
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+)
+
+// funcToHTML generates HTML table for a func, with its signature, name and source location.
+func funcToHTML(doc *htmlDocument, reflectedFunc reflect.Value) error {
+	if reflectedFunc.Kind() != reflect.Func {
+		return fmt.Errorf(`[funcToHTML] only accepts func, got %s`, reflectedFunc.Kind())
+	}
+
+	table := doc.newTable()
+	table.Caption(`func`).
+		funcBody(reflectedFunc).
+		write(doc)
+
+	return nil
+}
+
+func (table *tableT) funcBody(reflectedFunc reflect.Value) *tableT {
+	table.propertyRow(`Signature`, reflectedFunc.Type().String())
+
+	if reflectedFunc.IsNil() {
+		table.propertyRow(`Value`, NULL)
+
+		return table
+	}
+
+	function := runtime.FuncForPC(reflectedFunc.Pointer())
+	if function == nil {
+		return table
+	}
+
+	file, line := function.FileLine(function.Entry())
+
+	table.propertyRow(`Name`, function.Name())
+	table.propertyRow(`Location`, fmt.Sprintf(`%s:%d`, file, line))
+
+	return table
+}
+
+// propertyRow adds a row with the name of a property of the value and the property.
+func (table *tableT) propertyRow(name, value string) {
+	var row rowT
+
+	row.addCell(cellT{value: name, key: true})
+	row.addCellStr(value)
+
+	table.addBodyRow(row)
+	table.columns = len(row.cells)
+}
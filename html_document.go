@@ -1,50 +1,151 @@
 package htmldump
 
 import (
+	"bufio"
 	"fmt"
 	"io"
+	"strings"
 )
 
+// documentBufferSize is the size of the buffer between the document and its writer.
+const documentBufferSize = 64 * 1024
+
+// documentFormat is the markup the tables of a document are written in.
+type documentFormat int
+
+const (
+	formatHTML documentFormat = iota
+	formatMarkdown
+	formatText
+)
+
+// htmlDocument streams the document to its writer through a buffer, tables
+// are written as soon as they are complete. Documents are HTML unless their
+// format says otherwise.
 type htmlDocument struct {
-	writer io.Writer
-	body   string
+	writer *bufio.Writer
+	format documentFormat
+	tables int
+	dumper *Dumper
+	// dir is the directory of a paginated dump, the pages of large
+	// collections are written there.
+	dir string
 }
 
-func (doc *htmlDocument) save() (int, error) {
-	num, err := io.WriteString(doc.writer, doc.body)
+// save writes what is left in the buffer, and reports the first error
+// writing the document.
+func (doc *htmlDocument) save() error {
+	err := doc.writer.Flush()
 	if err != nil {
-		werr := fmt.Errorf("[(doc *htmlDocument) save()] writing to io.Writer error: %w", err)
-		return 0, werr
+		return fmt.Errorf("[(doc *htmlDocument) save()] writing to io.Writer error: %w", err)
 	}
 
-	return num, nil
+	return nil
+}
+
+// end completes the document after its last table.
+func (doc *htmlDocument) end() {
+	if doc.format == formatHTML {
+		doc.add("</body>\n</html>")
+	}
 }
 
-// Add HTML table row to the document body.
+// Add HTML markup to the document body.
 func (doc *htmlDocument) add(str string) *htmlDocument {
-	doc.body += str + "\n"
+	doc.writer.WriteString(str + "\n")
 
 	return doc
 }
 
-func newHTMLDocument(writer io.Writer) *htmlDocument {
+// newTable creates a table with an id unique within the document, so rows can
+// be linked to.
+func (doc *htmlDocument) newTable() *tableT {
+	doc.tables++
+
+	return &tableT{
+		id:      fmt.Sprintf(`table-%d`, doc.tables),
+		dumper:  doc.dumper,
+		doc:     doc,
+		visited: make(map[visitKey]visitT),
+		path:    make(map[visitKey]visitT),
+	}
+}
+
+// newTextDocument creates a document of the text format, which has no head.
+func newTextDocument(writer io.Writer, dumper *Dumper, format documentFormat) *htmlDocument {
+	return &htmlDocument{
+		writer: bufio.NewWriterSize(writer, documentBufferSize),
+		format: format,
+		dumper: dumper,
+	}
+}
+
+func newHTMLDocument(writer io.Writer, dumper *Dumper) *htmlDocument {
 	doc := &htmlDocument{
-		writer: writer,
-		body:   ``,
+		writer: bufio.NewWriterSize(writer, documentBufferSize),
+		dumper: dumper,
 	}
 
-	doc.body += `<!DOCTYPE html>
+	doc.add(`<!DOCTYPE html>
 <html>
 <head> 
   <style>
+` + dumper.themeStyle() + strings.TrimSuffix(defaultStyle, "\n"))
+
+	if dumper.sortFilter {
+		doc.add(strings.TrimSuffix(sortFilterStyle, "\n"))
+	}
+
+	if dumper.treeView {
+		doc.add(strings.TrimSuffix(treeViewStyle, "\n"))
+	}
+
+	for _, style := range dumper.styles {
+		doc.add(style)
+	}
+
+	doc.add(`  </style>    
+</head>
+
+<body>`)
+
+	if script, ok := dumper.reloadScript(); ok {
+		doc.add(script)
+	}
+
+	if dumper.sortFilter {
+		doc.add(sortFilterScript)
+	}
+
+	if dumper.treeView {
+		doc.add(treeViewScript)
+	}
+
+	// The head goes out right away, a browser reading a slow dump from an
+	// HTTP response starts rendering before the first table is complete.
+	doc.writer.Flush()
+
+	return doc
+}
+
+// defaultStyle is the built-in stylesheet of the document.
+const defaultStyle = `    body {
+        background: var(--page-background);
+        color: var(--page-text);
+    }
+
+    a {
+        color: var(--link);
+    }
+
     .styled-table {
         border-collapse: collapse;
         margin: 25px 0;
-        font-size: 0.9em;
-        font-family: sans-serif;
+        font-size: var(--font-size);
+        font-family: var(--font-family);
         min-width: 400px;
         box-shadow: 0 0 20px rgba(0, 0, 0, 0.15);
-        border: 2px solid rgb(150, 150, 150);
+        border: 2px solid var(--border);
         border-top: none;
     }
 
@@ -53,66 +154,187 @@ func newHTMLDocument(writer io.Writer) *htmlDocument {
         font-size: 1.5em;
         font-weight: bold;
         padding: 7px;
-        background-color: rgb(220, 220, 220);
-        border-top: 2px solid rgb(150, 150, 150);
-        border-left: 2px solid rgb(150, 150, 150);
-        border-right: 2px solid rgb(150, 150, 150);
+        background-color: var(--caption-background);
+        color: var(--caption-text);
+        border-top: 2px solid var(--border);
+        border-left: 2px solid var(--border);
+        border-right: 2px solid var(--border);
         border-bottom: none;
     }
         
     .styled-table thead tr {
         white-space: nowrap;
-        background-color: #009879;
-        color: #ffffff;
+        background-color: var(--header-background);
+        color: var(--header-text);
         text-align: center;
     }
 
     .styled-table thead tr th {
-        border: 1px solid #006e58;
+        border: 1px solid var(--header-border);
     }
 
     .styled-table thead tr:first-of-type {
-        border-top: 2px solid #009879;
+        border-top: 2px solid var(--header-background);
     }		
 
     .styled-table thead tr:last-of-type {
-        border-bottom: 2px solid #009879;
+        border-bottom: 2px solid var(--header-background);
     }	
 
     .styled-table tbody tr {
-        border-bottom: 1px solid #dddddd;
+        border-bottom: 1px solid var(--grid);
     }
 
     .styled-table tbody tr td.key{
-        color: #006650;
+        color: var(--key-text);
+        background: var(--key-background);
         font-weight: bold;
     }        
 
     .styled-table tbody tr td{
-        border-right: 1px solid #dddddd;
+        border-right: 1px solid var(--grid);
     }        
 
-    .styled-table tbody tr:nth-child(even) {background: rgb(230, 230, 230)}
-    .styled-table tbody tr:nth-child(odd) {background: rgb(250, 250, 250)}
+    .styled-table tbody tr:nth-child(even) {background: var(--even-row)}
+    .styled-table tbody tr:nth-child(odd) {background: var(--odd-row)}
 
     .styled-table th,
     .styled-table td {
         padding: 4px 7px;
     }
         
-    .styled-table tbody tr:hover {
-        color: #006650;
+    .styled-table.nested {
+        margin: 4px 0;
+        min-width: 0;
+        font-size: 1em;
+        box-shadow: none;
     }
-  </style>    
-</head>
 
-<body>
-  <script>
-    setInterval(function () {
-      location.reload();
-    }, 2000);
-  </script>
-`
+    .styled-table details summary {
+        cursor: pointer;
+        white-space: nowrap;
+    }
 
-	return doc
-}
+    .styled-table .dynamic-type {
+        display: inline-block;
+        margin-right: 6px;
+        padding: 0 4px;
+        border-radius: 3px;
+        background-color: var(--badge-background);
+        color: var(--badge-text);
+        font-size: 0.8em;
+        vertical-align: top;
+    }
+
+    nav.pages {
+        margin: 12px 0;
+        font-family: var(--font-family);
+        font-size: var(--font-size);
+    }
+
+    nav.pages a,
+    nav.pages span {
+        margin-right: 12px;
+    }
+
+    nav.pages span.disabled {
+        color: var(--muted);
+    }
+
+    .styled-table pre.text,
+    .styled-table pre.sql {
+        margin: 0;
+        white-space: pre-wrap;
+    }
+
+    .styled-table .raw-toggle,
+    .styled-table .raw-toggle ~ .raw,
+    .styled-table .raw-toggle:checked ~ .formatted {
+        display: none;
+    }
+
+    .styled-table .raw-toggle:checked ~ .raw {
+        display: block;
+    }
+
+    .styled-table .raw-toggle + label {
+        float: right;
+        margin-left: 8px;
+        color: var(--accent);
+        font-size: 0.8em;
+        cursor: pointer;
+        user-select: none;
+    }
+
+    .styled-table .raw-toggle:checked + label {
+        font-weight: bold;
+    }
+
+    .styled-table pre,
+    .styled-table .json {
+        font-family: var(--mono-font);
+    }
+
+    .styled-table .json-children {
+        padding-left: 16px;
+    }
+
+    .styled-table .json details,
+    .styled-table .json summary {
+        display: inline;
+    }
+
+    .styled-table .json details:not([open]) > summary::after {
+        content: " …";
+    }
+
+    .styled-table .json-count {
+        color: var(--muted);
+        font-size: 0.85em;
+    }
+
+    .styled-table .json-key {
+        color: var(--syntax-key);
+    }
+
+    .styled-table .json-string,
+    .styled-table .sql-string {
+        color: var(--syntax-string);
+    }
+
+    .styled-table .json-number {
+        color: var(--syntax-number);
+    }
+
+    .styled-table .json-literal,
+    .styled-table .sql-keyword {
+        color: var(--syntax-keyword);
+        font-weight: bold;
+    }
+
+    .styled-table .sql-comment {
+        color: var(--muted);
+        font-style: italic;
+    }
+
+    .styled-table pre.hexdump {
+        margin: 0;
+        font-size: 0.85em;
+    }
+
+    .styled-table tbody tr td.absent {
+        color: var(--muted);
+        text-align: center;
+    }
+
+    .styled-table tbody tr td.truncated,
+    .styled-table tfoot tr td.truncated {
+        color: var(--muted);
+        font-style: italic;
+        text-align: center;
+    }
+
+    .styled-table tbody tr:hover {
+        color: var(--row-hover);
+    }
+`
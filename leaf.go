@@ -0,0 +1,111 @@
+package htmldump
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+)
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	errorType         = reflect.TypeOf((*error)(nil)).Elem()
+	stringerType      = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+)
+
+// leafTypesT is a set of struct types shown as a single value instead of
+// being expanded field by field.
+type leafTypesT struct {
+	mutex sync.RWMutex
+	types map[reflect.Type]bool
+}
+
+// globalLeafTypes holds the leaf types registered with RegisterLeafType,
+// shared by every Dumper.
+var globalLeafTypes = leafTypesT{
+	types: map[reflect.Type]bool{
+		timeType: true,
+	},
+}
+
+// RegisterLeafType makes every Dumper show values of the struct type T as a single
+// cell, formatted with its formatter or with %v, instead of a column per field.
+// Types implementing encoding.TextMarshaler, such as uuid.UUID, decimal.Decimal
+// or netip.Addr, types implementing driver.Valuer, such as sql.NullString,
+// and types with a formatter are leaves without registration.
+func RegisterLeafType[T any]() {
+	globalLeafTypes.add(typeOf[T]())
+}
+
+// RegisterLeafTypes is RegisterLeafType for types known only at run time.
+func RegisterLeafTypes(types ...reflect.Type) {
+	globalLeafTypes.add(types...)
+}
+
+// WithLeafType makes the Dumper only show values of the struct type T as a single cell.
+func WithLeafType[T any]() Option {
+	return func(dumper *Dumper) {
+		dumper.leafTypes.add(typeOf[T]())
+	}
+}
+
+// WithLeafTypes is WithLeafType for types known only at run time.
+func WithLeafTypes(types ...reflect.Type) Option {
+	return func(dumper *Dumper) {
+		dumper.leafTypes.add(types...)
+	}
+}
+
+func (leafTypes *leafTypesT) add(types ...reflect.Type) {
+	leafTypes.mutex.Lock()
+	defer leafTypes.mutex.Unlock()
+
+	if leafTypes.types == nil {
+		leafTypes.types = make(map[reflect.Type]bool)
+	}
+
+	for _, valueType := range types {
+		leafTypes.types[valueType] = true
+	}
+
+	registryVersion.Add(1)
+}
+
+func (leafTypes *leafTypesT) contains(valueType reflect.Type) bool {
+	leafTypes.mutex.RLock()
+	defer leafTypes.mutex.RUnlock()
+
+	return leafTypes.types[valueType]
+}
+
+// isLeafType reports whether values of the type, or of the type it points to,
+// are shown as a single value. Types are matched by identity, so a struct
+// named Time from another package is not a leaf.
+func (dumper *Dumper) isLeafType(valueType reflect.Type) bool {
+	return dumper.typePlan(valueType).leaf
+}
+
+// isLeafElem reports whether the type, or the type it points to, is a leaf type
+// for another reason than a formatter of its own: registered, a text marshaler
+// or a driver.Valuer. Formatters are checked by the caller on the type itself,
+// as formatters of interfaces implemented by pointer receivers, such as error
+// for *errors.errorString, match the pointer type only.
+func (dumper *Dumper) isLeafElem(valueType reflect.Type) bool {
+	if valueType.Kind() == reflect.Pointer {
+		valueType = valueType.Elem()
+	}
+
+	return dumper.leafTypes.contains(valueType) ||
+		globalLeafTypes.contains(valueType) ||
+		valueType.Implements(textMarshalerType) ||
+		reflect.PointerTo(valueType).Implements(textMarshalerType) ||
+		isValuer(valueType)
+}
+
+// isStringer reports whether values of the type format themselves, being
+// errors or fmt.Stringer.
+func isStringer(valueType reflect.Type) bool {
+	return valueType.Implements(errorType) || valueType.Implements(stringerType)
+}
@@ -0,0 +1,56 @@
+package htmldump_test
+
+import (
+	"bytes"
+	"net/netip"
+	"testing"
+
+	"github.com/oslyak/htmldump"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Time shares its name with time.Time, but is a regular struct.
+type Time struct {
+	Hour   int
+	Minute int
+}
+
+type point struct {
+	X, Y int
+}
+
+type host struct {
+	Addr   netip.Addr
+	Opens  Time
+	Origin point
+	Labels struct {
+		Zone string
+	}
+}
+
+func TestLeafTypes(t *testing.T) {
+	t.Parallel()
+
+	input := host{
+		Addr:   netip.MustParseAddr(`192.168.0.1`),
+		Opens:  Time{Hour: 9, Minute: 30},
+		Origin: point{X: 1, Y: 2},
+	}
+	input.Labels.Zone = `eu`
+
+	buffer := bytes.NewBuffer([]byte{})
+	err := htmldump.NewDumper(htmldump.WithLeafType[point]()).Dump(buffer, input, []host{input})
+	require.NoError(t, err)
+
+	tables := removeStyle(t, extractHTMLTables(t, buffer.String()))
+
+	require.Contains(t, tables, `<tr><td>Addr</td><td>Addr</td><td>192.168.0.1</td></tr>`)
+	require.Contains(t, tables, `<tr><td>Opens</td><td>Time</td><td></td></tr><tr><td>Hour</td><td>int</td><td>9</td></tr>`)
+	require.Contains(t, tables, `<tr><td>Origin</td><td>point</td><td>{1 2}</td></tr>`)
+
+	require.Contains(t, tables, `<tr><td>Labels</td><td>struct { Zone string }</td><td></td></tr><tr><td>Zone</td><td>string</td><td>eu</td></tr>`)
+
+	require.Contains(t, tables, `<th>Addr</th><th colspan="2">Opens(Time)</th><th>Origin</th><th>Labels(struct { Zone string })</th>`)
+	require.Contains(t, tables, `<tr><td>0</td><td>192.168.0.1</td><td>9</td><td>30</td><td>{1 2}</td><td>eu</td></tr>`)
+}
@@ -0,0 +1,76 @@
+package htmldump
+
+import (
+	"fmt"
+	"strconv"
+	"unicode/utf8"
+)
+
+// Limits caps how much of a value is rendered, so a huge slice or a deep tree
+// still produces a page the browser can open. Zero means no limit.
+type Limits struct {
+	MaxDepth      int // levels of nested structs expanded in a struct table
+	MaxRows       int // body rows per table
+	MaxCellLength int // characters per cell value
+	MaxHexDump    int // bytes shown in a hex dump
+}
+
+// DefaultLimits are the limits a new Dumper starts with, and the ones used by
+// ToHTML and ToHTMLAndOpen.
+var DefaultLimits = Limits{
+	MaxDepth:      16,
+	MaxRows:       1000,
+	MaxCellLength: 4096,
+	MaxHexDump:    4096,
+}
+
+// depthExceeded reports whether the nesting level is beyond the depth limit.
+func (limits Limits) depthExceeded(level int) bool {
+	return limits.MaxDepth > 0 && level >= limits.MaxDepth
+}
+
+// rowsExceeded reports whether the table already holds the maximum number of rows.
+func (limits Limits) rowsExceeded(rows int) bool {
+	return limits.MaxRows > 0 && rows >= limits.MaxRows
+}
+
+// rowsTruncated reports whether a table of the rows is cut at the row limit.
+func (limits Limits) rowsTruncated(rows int) bool {
+	return limits.MaxRows > 0 && rows > limits.MaxRows
+}
+
+// truncateValue cuts the value to the maximum cell length. Trusted HTML is left
+// intact, cutting it would produce broken markup.
+func (limits Limits) truncateValue(cell *cellT) bool {
+	if limits.MaxCellLength <= 0 || cell.html || utf8.RuneCountInString(cell.value) <= limits.MaxCellLength {
+		return false
+	}
+
+	runes := []rune(cell.value)
+	more := len(runes) - limits.MaxCellLength
+
+	cell.value = fmt.Sprintf(`%s… %s more characters`, string(runes[:limits.MaxCellLength]), formatCount(more))
+
+	return true
+}
+
+// truncatedCell returns the marker cell shown in place of the values left out.
+func truncatedCell(value string, colspan int) cellT {
+	return cellT{value: value, colspan: colspan, truncated: true}
+}
+
+// formatCount formats a number with thousands separators: 1999950 -> 1,999,950.
+func formatCount(count int) string {
+	digits := strconv.Itoa(count)
+	sign := ``
+
+	if count < 0 {
+		sign, digits = `-`, digits[1:]
+	}
+
+	for idx := len(digits) - 3; idx > 0; idx -= 3 {
+		digits = digits[:idx] + `,` + digits[idx:]
+	}
+
+	return sign + digits
+}
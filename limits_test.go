@@ -0,0 +1,78 @@
+package htmldump_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/oslyak/htmldump"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimits(t *testing.T) {
+	t.Parallel()
+
+	limits := htmldump.DefaultLimits
+
+	slice := make([]int, limits.MaxRows+1_999_950)
+
+	buffer := bytes.NewBuffer([]byte{})
+	err := htmldump.ToHTML(buffer, slice)
+	require.NoError(t, err)
+
+	table := extractHTMLTable(t, buffer.String())
+	table = removeStyle(t, table)
+
+	require.Contains(t, table, `— output truncated</caption>`)
+	require.Contains(t, table, `colspan="2">… 1,999,950 more elements</td>`)
+	require.Equal(t, limits.MaxRows+1, strings.Count(table, `<tr>`)-2)
+
+	long := strings.Repeat(`x`, limits.MaxCellLength+10)
+
+	buffer = bytes.NewBuffer([]byte{})
+	err = htmldump.ToHTML(buffer, long)
+	require.NoError(t, err)
+
+	require.Contains(t, buffer.String(), `x… 10 more characters</pre></td>`)
+
+	first := &node{Value: 0}
+	last := first
+
+	for idx := 1; idx <= limits.MaxDepth+1; idx++ {
+		last.Next = &node{Value: idx}
+		last = last.Next
+	}
+
+	buffer = bytes.NewBuffer([]byte{})
+	err = htmldump.ToHTML(buffer, first)
+	require.NoError(t, err)
+
+	table = extractHTMLTable(t, buffer.String())
+	table = removeStyle(t, table)
+
+	require.Contains(t, table, `<caption>*struct node — output truncated</caption>`)
+	require.Contains(t, table, `>… depth limit reached</td>`)
+}
+
+func TestLimitsStreamedTables(t *testing.T) {
+	t.Parallel()
+
+	dumper := htmldump.NewDumper(htmldump.WithMaxCellLength(5))
+
+	buffer := bytes.NewBuffer([]byte{})
+	err := dumper.Dump(buffer, []string{`abcdefghij`}, []string{`abc`})
+	require.NoError(t, err)
+
+	tables := removeStyle(t, extractHTMLTables(t, buffer.String()))
+
+	require.Contains(t, tables, `<td>abcde… 5 more characters</td></tr></tbody>`+
+		`<tfoot><tr><td  colspan="2">… output truncated</td></tr></tfoot></table>`)
+	require.Equal(t, 1, strings.Count(tables, `<tfoot>`))
+
+	buffer = bytes.NewBuffer([]byte{})
+	err = dumper.DumpMarkdown(buffer, map[string]string{`a`: `abcdefghij`})
+	require.NoError(t, err)
+
+	require.Contains(t, buffer.String(), "| a | abcde… 5 more characters |\n| … output truncated |  |\n")
+}
@@ -16,11 +16,22 @@ func mapToHTML(doc *htmlDocument, reflectedMap reflect.Value) error {
 		return err
 	}
 
-	table := new(tableT)
+	if length := reflect.Indirect(reflectedMap).Len(); doc.paginates(length) {
+		entries := doc.dumper.mapEntries(reflect.Indirect(reflectedMap))
+
+		return doc.writePages(caption, length, func(table *tableT, from, to int) {
+			table.mapHeader(reflectedMap).
+				begin(to-from).
+				mapRange(entries, from, to)
+		})
+	}
+
+	table := doc.newTable()
 	table.Caption(caption).
 		mapHeader(reflectedMap).
+		begin(reflect.Indirect(reflectedMap).Len()).
 		mapBody(reflectedMap).
-		toHTML(doc)
+		end()
 
 	return nil
 }
@@ -32,41 +43,47 @@ func mapCaption(reflectedMap reflect.Value) (string, error) {
 		return ``, err
 	}
 
-	return fmt.Sprintf(`%s (length: %d)`, typeName, reflectedMap.Len()), nil
+	return fmt.Sprintf(`%s (length: %d)`, typeName, reflect.Indirect(reflectedMap).Len()), nil
 }
 
 func (table *tableT) mapHeader(reflectedMap reflect.Value) *tableT {
 	var captions, types rowT
 
-	captions.addCell(cellT{value: `map key`, key: true})
-	types.addCell(cellT{value: reflectedMap.Type().Key().Name(), key: true})
+	mapType := reflect.Indirect(reflectedMap).Type()
+
+	captions.addCell(sortableCell(cellT{value: `map key`, key: true}, 0, mapType.Key()))
+	types.addCell(sortableCell(cellT{value: getFieldTypeName(mapType.Key()), key: true}, 0, mapType.Key()))
 
-	table.headerRow(reflectedMap.Type().Elem(), &captions, &types)
+	table.headerRow(mapType.Elem(), &captions, &types)
 
 	return table
 }
 
 // Generate table body for a map.
 func (table *tableT) mapBody(reflectedMap reflect.Value) *tableT {
-	for _, key := range reflectedMap.MapKeys() {
-		var row rowT
+	entries := table.dumper.mapEntries(reflect.Indirect(reflectedMap))
+
+	return table.mapRange(entries, 0, len(entries))
+}
 
-		row.addCell(cellT{value: formatValue(key), key: true})
+// mapRange adds the rows of the entries from the index up to the end index.
+func (table *tableT) mapRange(entries []MapEntry, from, to int) *tableT {
+	for index := from; index < to; index++ {
+		if !table.paged && table.dumper.limits.rowsExceeded(index-from) {
+			table.addTruncatedRow(fmt.Sprintf(`… %s more entries`, formatCount(to-index)))
 
-		item := reflectedMap.MapIndex(key)
-		if item.Kind() == reflect.Pointer {
-			item = item.Elem()
+			break
 		}
 
-		switch {
-		case !item.IsValid():
-			row.addCell(cellT{value: NULL, colspan: table.columns - 1})
-		case item.Kind() == reflect.Struct:
-			structRow(&row, item)
-		default:
-			row.addCellStr(formatValue(item))
-		}
+		var row rowT
+
+		entry := entries[index]
+
+		keyCell := table.dumper.valueCell(entry.Key)
+		keyCell.key = true
+		row.addCell(keyCell)
 
+		table.itemCells(&row, entry.Value)
 		table.addBodyRow(row)
 	}
 
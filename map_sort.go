@@ -0,0 +1,171 @@
+package htmldump
+
+import (
+	"cmp"
+	"reflect"
+	"slices"
+)
+
+// MapOrder selects the order map entries are shown in.
+type MapOrder int
+
+const (
+	// MapOrderSorted sorts map entries by key with the rules fmt uses
+	// to print maps, so two dumps of the same map are identical.
+	MapOrderSorted MapOrder = iota
+	// MapOrderUnsorted keeps Go's own map iteration order, which is random.
+	// Go maps do not remember insertion order, so there is no way to show it.
+	MapOrderUnsorted
+)
+
+// MapEntry is a map key with its value, passed to the comparison set by WithMapCompare.
+type MapEntry struct {
+	Key   reflect.Value
+	Value reflect.Value
+}
+
+// mapSortT holds how map entries are ordered.
+type mapSortT struct {
+	order   MapOrder
+	field   string
+	compare func(a, b MapEntry) int
+}
+
+// WithMapOrder sets the order map entries are shown in, MapOrderSorted by default.
+func WithMapOrder(order MapOrder) Option {
+	return func(dumper *Dumper) {
+		dumper.mapSort.order = order
+	}
+}
+
+// WithMapSortByField sorts map entries by the named field of their struct
+// values, entries with equal fields by key. Values without the field,
+// such as nil pointers, come first.
+func WithMapSortByField(field string) Option {
+	return func(dumper *Dumper) {
+		dumper.mapSort.field = field
+	}
+}
+
+// WithMapCompare sorts map entries with a custom comparison returning a negative
+// number when a goes before b, a positive number when after and zero when
+// equal. It takes precedence over WithMapSortByField and WithMapOrder.
+func WithMapCompare(compare func(a, b MapEntry) int) Option {
+	return func(dumper *Dumper) {
+		dumper.mapSort.compare = compare
+	}
+}
+
+// mapEntries returns the entries of the map in the configured order.
+func (dumper *Dumper) mapEntries(reflectedMap reflect.Value) []MapEntry {
+	entries := make([]MapEntry, 0, reflectedMap.Len())
+
+	iterator := reflectedMap.MapRange()
+	for iterator.Next() {
+		entries = append(entries, MapEntry{Key: iterator.Key(), Value: iterator.Value()})
+	}
+
+	sorting := dumper.mapSort
+
+	switch {
+	case sorting.compare != nil:
+		slices.SortStableFunc(entries, sorting.compare)
+	case len(sorting.field) > 0:
+		slices.SortFunc(entries, func(a, b MapEntry) int {
+			return cmp.Or(
+				compareValues(entryField(a, sorting.field), entryField(b, sorting.field)),
+				compareValues(a.Key, b.Key),
+			)
+		})
+	case sorting.order == MapOrderSorted:
+		slices.SortFunc(entries, func(a, b MapEntry) int {
+			return compareValues(a.Key, b.Key)
+		})
+	}
+
+	return entries
+}
+
+// entryField returns the named field of the entry value, an invalid
+// reflect.Value when there is no such field.
+func entryField(entry MapEntry, name string) reflect.Value {
+	value := entry.Value
+
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Struct {
+		return reflect.Value{}
+	}
+
+	return value.FieldByName(name)
+}
+
+// compareValues orders values with the rules of fmt's map printing: numbers,
+// strings and bools by value, NaN before other floats, pointers and channels
+// by address, structs and arrays element by element, interfaces by concrete
+// type first. Invalid values and nil go first.
+func compareValues(a, b reflect.Value) int {
+	switch {
+	case !a.IsValid() || !b.IsValid():
+		return cmp.Compare(boolRank(a.IsValid()), boolRank(b.IsValid()))
+	case a.Kind() != b.Kind():
+		return cmp.Compare(a.Kind(), b.Kind())
+	}
+
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(a.Int(), b.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return cmp.Compare(a.Uint(), b.Uint())
+	case reflect.String:
+		return cmp.Compare(a.String(), b.String())
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(a.Float(), b.Float())
+	case reflect.Complex64, reflect.Complex128:
+		return cmp.Or(
+			cmp.Compare(real(a.Complex()), real(b.Complex())),
+			cmp.Compare(imag(a.Complex()), imag(b.Complex())),
+		)
+	case reflect.Bool:
+		return cmp.Compare(boolRank(a.Bool()), boolRank(b.Bool()))
+	case reflect.Pointer, reflect.UnsafePointer, reflect.Chan:
+		return cmp.Compare(a.Pointer(), b.Pointer())
+	case reflect.Struct:
+		for idx := 0; idx < a.NumField(); idx++ {
+			if result := compareValues(a.Field(idx), b.Field(idx)); result != 0 {
+				return result
+			}
+		}
+
+		return 0
+	case reflect.Array:
+		for idx := 0; idx < a.Len(); idx++ {
+			if result := compareValues(a.Index(idx), b.Index(idx)); result != 0 {
+				return result
+			}
+		}
+
+		return 0
+	case reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			return cmp.Compare(boolRank(!a.IsNil()), boolRank(!b.IsNil()))
+		}
+
+		return cmp.Or(
+			cmp.Compare(a.Elem().Type().String(), b.Elem().Type().String()),
+			compareValues(a.Elem(), b.Elem()),
+		)
+	default:
+		return 0
+	}
+}
+
+func boolRank(value bool) int {
+	if value {
+		return 1
+	}
+
+	return 0
+}
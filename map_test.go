@@ -2,7 +2,7 @@ package htmldump_test
 
 import (
 	"bytes"
-
+	"math"
 	"strings"
 	"testing"
 
@@ -47,11 +47,27 @@ func TestDumpMap(t *testing.T) {
 	table = extractHTMLTable(t, buffer.String())
 	table = removeStyle(t, table)
 
-	expect = `<caption>map[int64]*dump_test.filterAccounts (length: 5)</caption>`
+	expect = `<caption>map[int64]*htmldump_test.filterAccounts (length: 5)</caption>`
 	require.Contains(t, table, expect)
 
 	trBuilder := strings.Builder{}
 	trBuilder.WriteString(`<tr>`)
+	trBuilder.WriteString(`<th rowspan="2">IncludeRemoved(bool)</th>`)
+	trBuilder.WriteString(`<th rowspan="2">Limit(uint64)</th>`)
+	trBuilder.WriteString(`<th rowspan="2">Offset(uint64)</th>`)
+	trBuilder.WriteString(`<th>orderPtr(*order)</th>`)
+	trBuilder.WriteString(`<th>order</th>`)
+	trBuilder.WriteString(`<th rowspan="2">IncludeRemoved(bool)</th>`)
+	trBuilder.WriteString(`<th rowspan="2">Limit(uint64)</th>`)
+	trBuilder.WriteString(`<th rowspan="2">Offset(uint64)</th>`)
+	trBuilder.WriteString(`<th>orderPtr(*order)</th>`)
+	trBuilder.WriteString(`<th>order</th>`)
+	trBuilder.WriteString(`<th rowspan="2">IncludeRemoved(bool)</th>`)
+	trBuilder.WriteString(`<th rowspan="2">Limit(uint64)</th>`)
+	trBuilder.WriteString(`<th rowspan="2">Offset(uint64)</th>`)
+	trBuilder.WriteString(`<th>orderPtr(*order)</th>`)
+	trBuilder.WriteString(`<th>order</th>`)
+	trBuilder.WriteString(`</tr><tr>`)
 	trBuilder.WriteString(`<th>int64</th>`)
 	trBuilder.WriteString(`<th>string</th>`)
 	trBuilder.WriteString(`<th>int64</th>`)
@@ -59,23 +75,63 @@ func TestDumpMap(t *testing.T) {
 	trBuilder.WriteString(`<th>Time</th>`)
 	trBuilder.WriteString(`<th>*Time</th>`)
 	trBuilder.WriteString(`<th>*Time</th>`)
-	trBuilder.WriteString(`<th>IncludeRemoved(bool)</th>`)
-	trBuilder.WriteString(`<th>Limit(uint64)</th>`)
-	trBuilder.WriteString(`<th>Offset(uint64)</th>`)
-	trBuilder.WriteString(`<th>orderPtr(*order)</th>`)
-	trBuilder.WriteString(`<th>order</th>`)
-	trBuilder.WriteString(`<th>IncludeRemoved(bool)</th>`)
-	trBuilder.WriteString(`<th>Limit(uint64)</th>`)
-	trBuilder.WriteString(`<th>Offset(uint64)</th>`)
-	trBuilder.WriteString(`<th>orderPtr(*order)</th>`)
-	trBuilder.WriteString(`<th>order</th>`)
-	trBuilder.WriteString(`<th>IncludeRemoved(bool)</th>`)
-	trBuilder.WriteString(`<th>Limit(uint64)</th>`)
-	trBuilder.WriteString(`<th>Offset(uint64)</th>`)
-	trBuilder.WriteString(`<th>orderPtr(*order)</th>`)
-	trBuilder.WriteString(`<th>order</th>`)
+	trBuilder.WriteString(strings.Repeat(`<th>Column(string)</th>`, 6))
 	trBuilder.WriteString(`</tr>`)
 
 	expect = trBuilder.String()
 	require.Contains(t, table, expect)
 }
+
+func TestDumpMapOrder(t *testing.T) {
+	t.Parallel()
+
+	type point struct {
+		X, Y int
+	}
+
+	type player struct {
+		Name  string
+		Score int
+	}
+
+	numbers := map[int]string{10: `ten`, -1: `minus one`, 2: `two`}
+	floats := map[float64]bool{2.5: true, math.NaN(): false, -3: true}
+	points := map[point]bool{{X: 2, Y: 1}: true, {X: 1, Y: 5}: false, {X: 1, Y: 2}: true}
+	players := map[string]*player{
+		`c`: {Name: `Carol`, Score: 7},
+		`a`: {Name: `Alice`, Score: 9},
+		`b`: {Name: `Bob`, Score: 1},
+		`n`: nil,
+	}
+
+	buffer := bytes.NewBuffer([]byte{})
+	err := htmldump.ToHTML(buffer, numbers, floats, points, players)
+	require.NoError(t, err)
+
+	tables := removeStyle(t, extractHTMLTables(t, buffer.String()))
+
+	require.Contains(t, tables, `<tr><td>-1</td><td>minus one</td></tr><tr><td>2</td><td>two</td></tr><tr><td>10</td><td>ten</td></tr>`)
+	require.Contains(t, tables, `<tr><td>NaN</td><td>false</td></tr><tr><td>-3</td><td>true</td></tr><tr><td>2.5</td><td>true</td></tr>`)
+	require.Contains(t, tables, `<tr><td>{1 2}</td><td>true</td></tr><tr><td>{1 5}</td><td>false</td></tr><tr><td>{2 1}</td><td>true</td></tr>`)
+	require.Contains(t, tables, `<tr><td>a</td><td>Alice</td><td>9</td></tr><tr><td>b</td><td>Bob</td><td>1</td></tr>`+
+		`<tr><td>c</td><td>Carol</td><td>7</td></tr><tr><td>n</td><td colspan="2">NULL</td></tr>`)
+
+	buffer = bytes.NewBuffer([]byte{})
+	err = htmldump.NewDumper(htmldump.WithMapSortByField(`Score`)).Dump(buffer, players)
+	require.NoError(t, err)
+
+	tables = removeStyle(t, extractHTMLTables(t, buffer.String()))
+	require.Contains(t, tables, `<tr><td>n</td><td colspan="2">NULL</td></tr><tr><td>b</td><td>Bob</td><td>1</td></tr>`+
+		`<tr><td>c</td><td>Carol</td><td>7</td></tr><tr><td>a</td><td>Alice</td><td>9</td></tr>`)
+
+	byKeyDescending := htmldump.WithMapCompare(func(a, b htmldump.MapEntry) int {
+		return strings.Compare(b.Key.String(), a.Key.String())
+	})
+
+	buffer = bytes.NewBuffer([]byte{})
+	err = htmldump.NewDumper(byKeyDescending).Dump(buffer, players)
+	require.NoError(t, err)
+
+	tables = removeStyle(t, extractHTMLTables(t, buffer.String()))
+	require.Contains(t, tables, `<tbody><tr><td>n</td><td colspan="2">NULL</td></tr><tr><td>c</td>`)
+}
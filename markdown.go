@@ -0,0 +1,265 @@
+package htmldump
+
+import (
+	"errors"
+	"io"
+	"strings"
+)
+
+// markdownHeading is the heading level of the captions of top level tables,
+// nested tables follow with the next levels.
+const markdownHeading = 2
+
+// markdownEscaper keeps values from being taken for markup. Values of the
+// HTML type are not escaped, GitHub renders the safe part of HTML.
+var markdownEscaper = strings.NewReplacer(`&`, `&amp;`, `<`, `&lt;`, `>`, `&gt;`)
+
+// markdownCellEscaper keeps a value within its table cell. Backslashes are
+// escaped too, so one before a pipe does not escape the escaping backslash.
+var markdownCellEscaper = strings.NewReplacer(`\`, `\\`, `|`, `\|`, "\r\n", `<br>`, "\n", `<br>`, "\r", `<br>`)
+
+// ToMarkdown dumps the inputs to the writer as GitHub-flavoured Markdown
+// tables, for pasting into pull requests and issues.
+// It is a shortcut for DumpMarkdown of a Dumper with the default options.
+func ToMarkdown(writer io.Writer, inputs ...interface{}) error {
+	if len(inputs) == 0 {
+		return errors.New(`[ToMarkdown] requires at least one inputs argument`)
+	}
+
+	return defaultDumper().DumpMarkdown(writer, inputs...)
+}
+
+// DumpMarkdown writes the inputs to the writer as GitHub-flavoured Markdown.
+// Captions become headings, nested tables follow the table they are nested in,
+// and grouped header columns get dotted names.
+func (dumper *Dumper) DumpMarkdown(writer io.Writer, inputs ...interface{}) error {
+	if len(inputs) == 0 {
+		return errors.New(`[DumpMarkdown] requires at least one inputs argument`)
+	}
+
+	return dumper.dumpDocument(newTextDocument(writer, dumper, formatMarkdown), inputs)
+}
+
+// writeMarkdown writes the table with a caption heading of the level, then the
+// tables nested in its cells one level deeper.
+func (table *tableT) writeMarkdown(writer htmlWriter, heading int) {
+	table.writeMarkdownHead(writer, heading)
+
+	var nested []*tableT
+
+	for idx := range table.body {
+		nested = append(nested, table.writeMarkdownRow(writer, &table.body[idx])...)
+	}
+
+	table.writeMarkdownTail(writer, heading, nested)
+}
+
+// writeMarkdownHead writes the caption heading and the header of the table.
+func (table *tableT) writeMarkdownHead(writer htmlWriter, heading int) {
+	caption := table.caption
+	if table.truncated {
+		caption += ` — output truncated`
+	}
+
+	if table.nested {
+		caption = table.id + `: ` + caption
+	}
+
+	if len(caption) > 0 {
+		writer.WriteString(strings.Repeat(`#`, min(heading, 6)) + ` ` + escapeMarkdown(caption) + "\n\n")
+	}
+
+	names := table.columnNames()
+
+	for idx := range names {
+		names[idx] = escapeMarkdown(names[idx])
+	}
+
+	writer.WriteString(markdownRow(names))
+	writer.WriteString(strings.Repeat(`| --- `, len(names)) + "|\n")
+}
+
+// writeMarkdownRow writes the body row, and returns the tables nested in it.
+func (table *tableT) writeMarkdownRow(writer htmlWriter, row *rowT) []*tableT {
+	var nested []*tableT
+
+	values := make([]string, 0, table.columns)
+
+	for _, cell := range row.cells {
+		if cell.nested != nil {
+			nested = append(nested, cell.nested)
+		}
+
+		values = append(values, markdownValue(&cell))
+
+		for range max(cell.colspan, 1) - 1 {
+			values = append(values, ``)
+		}
+	}
+
+	for len(values) < table.columns {
+		values = append(values, ``)
+	}
+
+	writer.WriteString(markdownRow(values))
+
+	return nested
+}
+
+// writeMarkdownTail ends the table, with a row noting a truncation the caption
+// did not report, then writes the nested tables one level deeper.
+func (table *tableT) writeMarkdownTail(writer htmlWriter, heading int, nested []*tableT) {
+	if table.lateTruncation() {
+		var row rowT
+
+		row.addCell(truncatedCell(truncationNote, table.columns))
+		table.writeMarkdownRow(writer, &row)
+	}
+
+	writer.WriteString("\n")
+
+	for _, table := range nested {
+		table.writeMarkdown(writer, heading+1)
+	}
+}
+
+func markdownRow(values []string) string {
+	return `| ` + strings.Join(values, ` | `) + " |\n"
+}
+
+// markdownValue returns the cell value as Markdown. Nested tables are shown
+// as a pointer to where they follow, indented struct fields keep their indent.
+func markdownValue(cell *cellT) string {
+	var value string
+
+	switch {
+	case cell.nested != nil:
+		value = `↓ ` + cell.nested.id
+	case cell.html && len(cell.text) > 0:
+		value = escapeMarkdown(cell.text)
+	case cell.html:
+		value = markdownCellEscaper.Replace(cell.value)
+	default:
+		value = escapeMarkdown(cell.value)
+	}
+
+	if len(cell.dynamicType) > 0 {
+		value = "`" + cell.dynamicType + "` " + value
+	}
+
+	return strings.Repeat(`&nbsp;`, cell.paddingLeft/3) + value
+}
+
+func escapeMarkdown(text string) string {
+	return markdownCellEscaper.Replace(markdownEscaper.Replace(text))
+}
+
+// columnNames returns a name per column of the table. Tables without a header,
+// such as the ones of scalars, get empty names for the columns of their widest
+// body row.
+func (table *tableT) columnNames() []string {
+	if len(table.header) > 0 {
+		return columnNames(table.header)
+	}
+
+	columns := 0
+
+	for _, row := range table.body {
+		width := 0
+		for _, cell := range row.cells {
+			width += max(cell.colspan, 1)
+		}
+
+		columns = max(columns, width)
+	}
+
+	return make([]string, columns)
+}
+
+// columnNames returns a name per column of the header rows. The names of
+// header groups spanning several columns are joined with dots. When the last
+// row holds the type of a column named above it, the type follows the name
+// in parentheses.
+func columnNames(header []rowT) []string {
+	grid := headerGrid(header)
+	last := len(grid) - 1
+
+	width := 0
+	for _, row := range grid {
+		width = max(width, len(row))
+	}
+
+	names := make([]string, 0, width)
+
+	for column := range width {
+		var (
+			parts    []string
+			typeName string
+			previous *cellT
+		)
+
+		for idx, row := range grid {
+			if column >= len(row) || row[column] == nil || row[column] == previous {
+				continue
+			}
+
+			cell := row[column]
+
+			switch {
+			case idx == last && previous != nil && len(previous.title) == 0:
+				typeName = cell.value
+			case len(cell.title) > 0:
+				parts = append(parts, cell.title)
+			default:
+				parts = append(parts, cell.value)
+			}
+
+			previous = cell
+		}
+
+		name := strings.Join(parts, `.`)
+		if len(typeName) > 0 {
+			name += `(` + typeName + `)`
+		}
+
+		names = append(names, name)
+	}
+
+	return names
+}
+
+// headerGrid places the header cells on a grid of rows by columns, cells
+// spanning several rows or columns take every place they cover.
+func headerGrid(header []rowT) [][]*cellT {
+	grid := make([][]*cellT, len(header))
+
+	place := func(row, column int, cell *cellT) {
+		for len(grid[row]) <= column {
+			grid[row] = append(grid[row], nil)
+		}
+
+		grid[row][column] = cell
+	}
+
+	for rowIdx := range header {
+		column := 0
+
+		for cellIdx := range header[rowIdx].cells {
+			cell := &header[rowIdx].cells[cellIdx]
+
+			for column < len(grid[rowIdx]) && grid[rowIdx][column] != nil {
+				column++
+			}
+
+			for row := rowIdx; row < min(rowIdx+max(cell.rowspan, 1), len(grid)); row++ {
+				for span := range max(cell.colspan, 1) {
+					place(row, column+span, cell)
+				}
+			}
+
+			column += max(cell.colspan, 1)
+		}
+	}
+
+	return grid
+}
@@ -0,0 +1,49 @@
+package htmldump_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/oslyak/htmldump"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToMarkdown(t *testing.T) {
+	t.Parallel()
+
+	orders := []customerOrder{
+		{ID: 1, Customer: customer{Name: "Ann | Co\nLtd", Address: &postalAddress{City: `Oslo`, Street: `Main`}}},
+		{ID: 2, Customer: customer{Name: `Bob`}},
+	}
+
+	buffer := bytes.NewBuffer([]byte{})
+	err := htmldump.ToMarkdown(buffer, orders, map[string][]int{`a`: {1, 2}}, newFilterAccounts())
+	require.NoError(t, err)
+
+	markdown := buffer.String()
+
+	require.Contains(t, markdown, "## []htmldump_test.customerOrder (length: 2)\n\n"+
+		"| index(int) | ID(int) | Customer.Name(string) | Customer.Address.City(string) | Customer.Address.Street(string) |\n"+
+		"| --- | --- | --- | --- | --- |\n"+
+		"| 0 | 1 | Ann \\| Co<br>Ltd | Oslo | Main |\n"+
+		"| 1 | 2 | Bob | NULL |  |\n")
+	require.Contains(t, markdown, "| map key(string) | value([]int) |\n| --- | --- |\n| a | ↓ table-3 |\n")
+	require.Contains(t, markdown, "### table-3: []int (length: 2)\n\n| index(int) | value(int) |\n")
+	require.Contains(t, markdown, "| Field | Type | Value |\n")
+	require.Contains(t, markdown, "| &nbsp;&nbsp;&nbsp;&nbsp;&nbsp;&nbsp;&nbsp;&nbsp;Column | string |")
+	require.NotContains(t, markdown, `<table`)
+	require.NotContains(t, markdown, `</html>`)
+}
+
+func TestToMarkdownHeaderless(t *testing.T) {
+	t.Parallel()
+
+	buffer := bytes.NewBuffer([]byte{})
+	err := htmldump.ToMarkdown(buffer, 42, `a\|b`)
+	require.NoError(t, err)
+
+	markdown := buffer.String()
+
+	require.Contains(t, markdown, "## int\n\n|  |  |\n| --- | --- |\n| Value | 42 |\n")
+	require.Contains(t, markdown, "|  |  |\n| --- | --- |\n| Value | a\\\\\\|b |\n")
+}
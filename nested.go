@@ -0,0 +1,211 @@
+package htmldump
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// isNestedCollection reports whether values of the type are shown as a nested
+// table: slices, arrays and maps, or pointers to them. Byte slices and arrays
+// are kept as values.
+func isNestedCollection(valueType reflect.Type) bool {
+	if valueType.Kind() == reflect.Pointer {
+		valueType = valueType.Elem()
+	}
+
+	switch valueType.Kind() {
+	case reflect.Slice, reflect.Array:
+		return valueType.Elem().Kind() != reflect.Uint8
+	case reflect.Map:
+		return true
+	default:
+		return false
+	}
+}
+
+// valueCell returns the cell for a value shown at the nesting level of the table.
+// Slices and maps become nested tables unless the tag formats them as a value.
+func (table *tableT) valueCell(value reflect.Value, tag fieldTagT, level int) cellT {
+	if tag.collapse || len(tag.format) > 0 {
+		return table.dumper.fieldCell(value, tag)
+	}
+
+	switch {
+	case value.Kind() == reflect.Interface:
+		return table.dynamicCell(value, tag, level)
+	case isNestedCollection(value.Type()):
+		return table.nestedCell(value, level)
+	default:
+		return table.dumper.fieldCell(value, tag)
+	}
+}
+
+// dynamicCell returns the cell for an interface value, such as an element of
+// []any, badged with the type of the value it holds. Structs, slices and maps
+// held by the interface become nested tables. Errors and fmt.Stringer values
+// are formatted through the interface like %v, so methods with pointer
+// receivers are found too.
+func (table *tableT) dynamicCell(value reflect.Value, tag fieldTagT, level int) cellT {
+	if value.IsNil() {
+		return cellT{value: NULL}
+	}
+
+	held := value.Elem()
+
+	var cell cellT
+
+	switch {
+	case isStringer(held.Type()):
+		cell = table.dumper.fieldCell(value, tag)
+	case table.dumper.isExpandable(held.Type()):
+		cell = table.structCell(held, level)
+	default:
+		cell = table.valueCell(held, tag, level)
+	}
+
+	if len(cell.dynamicType) == 0 {
+		cell.dynamicType = getFieldTypeName(held.Type())
+	}
+
+	return cell
+}
+
+// nestedCell builds the table for a slice or map shown inside a cell.
+func (table *tableT) nestedCell(value reflect.Value, level int) cellT {
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return cellT{value: NULL}
+		}
+
+		value = value.Elem()
+	}
+
+	switch {
+	case value.Kind() != reflect.Array && value.IsNil():
+		return cellT{value: NULL}
+	case value.Len() == 0:
+		return table.dumper.valueCell(value)
+	}
+
+	nested, ok := table.nestedTable(level)
+	if !ok {
+		return truncatedCell(`… depth limit reached`, 0)
+	}
+
+	leave, seen, ok := table.enter(value, visitT{anchor: nested.id, label: `↺ see ` + nested.id})
+	if ok {
+		return cellT{value: seen.label, link: seen.anchor}
+	}
+	defer leave()
+
+	if value.Kind() == reflect.Map {
+		caption, _ := mapCaption(value)
+		nested.Caption(caption).
+			mapHeader(value).
+			mapBody(value)
+	} else {
+		caption, _ := sliceCaption(value)
+		nested.Caption(caption).
+			sliceHeader(value, 0, value.Len()).
+			sliceBody(value)
+	}
+
+	if nested.truncated {
+		table.truncated = true
+	}
+
+	return cellT{nested: nested}
+}
+
+// structCell builds the table for a struct shown inside a cell.
+func (table *tableT) structCell(value reflect.Value, level int) cellT {
+	if value.Kind() == reflect.Pointer && value.IsNil() {
+		return cellT{value: NULL}
+	}
+
+	nested, ok := table.nestedTable(level)
+	if !ok {
+		return truncatedCell(`… depth limit reached`, 0)
+	}
+
+	leave, seen, ok := table.enter(value, visitT{anchor: nested.id, label: `↺ see ` + nested.id})
+	if ok {
+		return cellT{value: seen.label, link: seen.anchor}
+	}
+	defer leave()
+
+	if seen, ok := table.seen(value); ok {
+		return cellT{value: seen.label, link: seen.anchor}
+	}
+
+	nested.Caption(structCaption(value.Type())).
+		structHeader().
+		structBody(value.Interface(), 0)
+
+	if nested.truncated {
+		table.truncated = true
+	}
+
+	return cellT{nested: nested}
+}
+
+// nestedTable returns a new table shown inside a cell at the nesting level of
+// the table. It reports false, marking the table truncated, past the depth limit.
+func (table *tableT) nestedTable(level int) (*tableT, bool) {
+	depth := table.depth + level + 1
+	if table.dumper.limits.depthExceeded(depth) {
+		table.truncated = true
+
+		return nil, false
+	}
+
+	nested := table.doc.newTable()
+	nested.nested = true
+	nested.depth = depth
+	nested.visited = table.visited
+	nested.path = table.path
+
+	return nested, true
+}
+
+// enter adds the pointer, slice or map to the path of values being rendered,
+// the returned function removes it. If the value is already on the path,
+// rendering it again would never end, so the place it is shown is returned.
+func (table *tableT) enter(value reflect.Value, visit visitT) (func(), visitT, bool) {
+	switch value.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Map:
+		if value.IsNil() {
+			return func() {}, visitT{}, false
+		}
+	default:
+		return func() {}, visitT{}, false
+	}
+
+	key := visitKey{address: value.Pointer(), valueType: value.Type()}
+
+	if seen, ok := table.path[key]; ok {
+		return nil, seen, true
+	}
+
+	table.path[key] = visit
+
+	return func() { delete(table.path, key) }, visitT{}, false
+}
+
+// enterRow adds a pointer element of the table to the path, linking to its row.
+func (table *tableT) enterRow(row *rowT, item reflect.Value) (func(), visitT, bool) {
+	// Slices and maps are entered by the nested tables showing them.
+	if item.Kind() != reflect.Pointer {
+		return func() {}, visitT{}, false
+	}
+
+	number := table.rows + 1
+	anchor := fmt.Sprintf(`%s-row-%d`, table.id, number)
+
+	leave, seen, ok := table.enter(item, visitT{anchor: anchor, label: fmt.Sprintf(`↺ see row %d of %s`, number, table.id)})
+	if !ok && !item.IsNil() {
+		row.id = anchor
+	}
+
+	return leave, seen, ok
+}
@@ -0,0 +1,150 @@
+package htmldump_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/oslyak/htmldump"
+
+	"github.com/stretchr/testify/require"
+)
+
+type item struct {
+	SKU   string
+	Count int
+}
+
+type purchase struct {
+	ID    int
+	Items []item
+	Tags  map[string]int
+	Notes []string
+}
+
+type treeNode struct {
+	Name     string
+	Parent   *treeNode
+	Children []*treeNode
+}
+
+func TestNestedTables(t *testing.T) {
+	t.Parallel()
+
+	input := purchase{
+		ID:    1,
+		Items: []item{{SKU: `apple`, Count: 2}, {SKU: `pear`, Count: 1}},
+		Tags:  map[string]int{`fresh`: 1},
+	}
+
+	buffer := bytes.NewBuffer([]byte{})
+	err := htmldump.ToHTML(buffer, input, []purchase{input})
+	require.NoError(t, err)
+
+	tables := removeStyle(t, extractHTMLTables(t, buffer.String()))
+
+	require.Contains(t, tables, `<tr><td>Items</td><td>[]htmldump_test.item</td><td><details open><summary>[]htmldump_test.item (length: 2)</summary>`+
+		`<table  ><thead><tr><th>index</th><th>SKU</th><th>Count</th></tr><tr><th>int</th><th>string</th><th>int</th></tr></thead>`+
+		`<tbody><tr><td>0</td><td>apple</td><td>2</td></tr><tr><td>1</td><td>pear</td><td>1</td></tr></tbody></table></details></td></tr>`)
+	require.Contains(t, tables, `<summary>map[string]int (length: 1)</summary>`)
+	require.Contains(t, tables, `<tr><td>Notes</td><td>[]string</td><td>NULL</td></tr>`)
+	require.Contains(t, tables, `<tr><td>0</td><td>1</td><td><details open><summary>[]htmldump_test.item (length: 2)</summary>`)
+
+	root := &treeNode{Name: `root`}
+	root.Children = []*treeNode{{Name: `left`, Parent: root}, {Name: `right`, Parent: root}}
+
+	buffer = bytes.NewBuffer([]byte{})
+	err = htmldump.NewDumper(htmldump.WithCollapsedNested()).Dump(buffer, root, root.Children)
+	require.NoError(t, err)
+
+	doc := buffer.String()
+
+	require.Contains(t, doc, `<details><summary>[]*htmldump_test.treeNode (length: 2)</summary>`)
+	require.Contains(t, doc, `<a href="#table-1">↺ see top of table</a>`)
+	require.Less(t, strings.Count(doc, `<table`), 10)
+}
+
+type holder struct {
+	Value any
+}
+
+func TestDynamicTypes(t *testing.T) {
+	t.Parallel()
+
+	values := []any{
+		7,
+		nil,
+		item{SKU: `apple`, Count: 2},
+		[]string{`a`},
+		map[string]any{`n`: 1.5},
+	}
+
+	buffer := bytes.NewBuffer([]byte{})
+	err := htmldump.ToHTML(buffer, values, holder{Value: &item{SKU: `pear`}})
+	require.NoError(t, err)
+
+	tables := removeStyle(t, extractHTMLTables(t, buffer.String()))
+
+	require.Contains(t, tables, `<tr><th>index</th><th>value</th></tr><tr><th>int</th><th>interface {}</th></tr>`)
+	require.Contains(t, tables, `<tr><td>0</td><td><span >int</span>7</td></tr>`)
+	require.Contains(t, tables, `<tr><td>1</td><td>NULL</td></tr>`)
+	require.Contains(t, tables, `<tr><td>2</td><td><span >item</span><details open><summary>struct item</summary>`+
+		`<table  ><thead><tr><th>Field</th><th>Type</th><th>Value</th></tr></thead>`+
+		`<tbody><tr><td>SKU</td><td>string</td><td>apple</td></tr><tr><td>Count</td><td>int</td><td>2</td></tr></tbody></table></details></td></tr>`)
+	require.Contains(t, tables, `<tr><td>3</td><td><span >[]string</span><details open><summary>[]string (length: 1)</summary>`)
+	require.Contains(t, tables, `<tr><td>n</td><td><span >float64</span>1.5</td></tr>`)
+	require.Contains(t, tables, `<tr><td>Value</td><td>interface {}</td><td><span >*item</span><details open><summary>*struct item</summary>`)
+}
+
+func TestNestedSlicesAndMaps(t *testing.T) {
+	t.Parallel()
+
+	buffer := bytes.NewBuffer([]byte{})
+	err := htmldump.ToHTML(buffer, [][]int{{1, 2}, {3}}, []map[string][]int{{`a`: {7, 8}}})
+	require.NoError(t, err)
+
+	tables := removeStyle(t, extractHTMLTables(t, buffer.String()))
+
+	require.NotContains(t, tables, `↺`)
+	require.Contains(t, tables, `<tr><td>1</td><td>2</td></tr>`)
+	require.Contains(t, tables, `<tr><td>0</td><td>3</td></tr>`)
+	require.Contains(t, tables, `<tr><td>1</td><td>8</td></tr>`)
+}
+
+type labelled struct {
+	Name string
+}
+
+func (value labelled) String() string {
+	return `label ` + value.Name
+}
+
+type failure struct {
+	Err error
+}
+
+func TestDynamicErrorsAndStringers(t *testing.T) {
+	t.Parallel()
+
+	boom := errors.New(`boom`)
+
+	buffer := bytes.NewBuffer([]byte{})
+	err := htmldump.ToHTML(buffer,
+		failure{Err: boom},
+		[]error{boom, fmt.Errorf(`saving: %w`, boom)},
+		map[string]error{`a`: boom},
+		[]any{labelled{Name: `x`}},
+	)
+	require.NoError(t, err)
+
+	tables := removeStyle(t, extractHTMLTables(t, buffer.String()))
+
+	require.Contains(t, tables, `<tr><td>Err</td><td>error</td><td><span >*errorString</span>boom</td></tr>`)
+	require.Contains(t, tables, `<tr><td>0</td><td><span >*errorString</span>boom</td></tr>`)
+	require.Contains(t, tables, `<tr><td>1</td><td><span >*wrapError</span>saving: boom</td></tr>`)
+	require.Contains(t, tables, `<tr><td>a</td><td><span >*errorString</span>boom</td></tr>`)
+	require.Contains(t, tables, `<tr><td>0</td><td><span >labelled</span>label x</td></tr>`)
+	require.NotContains(t, tables, `<details`)
+}
@@ -0,0 +1,165 @@
+package htmldump
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const (
+	// DefaultPageSize is the number of rows per page of a paginated dump.
+	DefaultPageSize = 1000
+	// IndexFile is the page of a paginated dump showing the inputs.
+	IndexFile = `index.html`
+)
+
+// WithPageSize sets the number of rows per page written by DumpDir, DefaultPageSize by default.
+func WithPageSize(rows int) Option {
+	return func(dumper *Dumper) {
+		dumper.pageSize = rows
+	}
+}
+
+// DumpDir writes the inputs to IndexFile in the directory, creating it if needed.
+// Slices, arrays and maps with more elements than fit on a page are split into
+// pages, written next to the index as table-1-0001.html, table-1-0002.html and
+// so on, and the index lists the pages. Every row of them is shown, the row
+// limit applies to nested tables only.
+func (dumper *Dumper) DumpDir(dir string, inputs ...interface{}) error {
+	if len(inputs) == 0 {
+		return errors.New(`[DumpDir] requires at least one inputs argument`)
+	}
+
+	err := os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return fmt.Errorf("[DumpDir] directory %s creating error: %w", dir, err)
+	}
+
+	return writeFile(filepath.Join(dir, IndexFile), func(file *os.File) error {
+		doc := newHTMLDocument(file, dumper)
+		doc.dir = dir
+
+		return dumper.dumpDocument(doc, inputs)
+	})
+}
+
+// writeFile creates the file and writes it with the function.
+func writeFile(path string, write func(file *os.File) error) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("[writeFile] file %s creating error: %w", path, err)
+	}
+
+	err = write(file)
+	if err != nil {
+		file.Close()
+
+		return err
+	}
+
+	err = file.Close()
+	if err != nil {
+		return fmt.Errorf("[writeFile] file %s closing error: %w", path, err)
+	}
+
+	return nil
+}
+
+// paginates reports whether a collection of the length is split into pages.
+func (doc *htmlDocument) paginates(length int) bool {
+	return len(doc.dir) > 0 && length > doc.dumper.rowsPerPage()
+}
+
+func (dumper *Dumper) rowsPerPage() int {
+	if dumper.pageSize > 0 {
+		return dumper.pageSize
+	}
+
+	return DefaultPageSize
+}
+
+// writePages writes a page per range of rows of the collection, and the table
+// of the pages to the document. The page function adds the header, begins the
+// table and adds the rows.
+func (doc *htmlDocument) writePages(caption string, length int, page func(table *tableT, from, to int)) error {
+	size := doc.dumper.rowsPerPage()
+	pages := (length + size - 1) / size
+
+	index := doc.newTable()
+	index.Caption(fmt.Sprintf(`%s — %s pages`, caption, formatCount(pages)))
+
+	var captions rowT
+
+	captions.addCell(cellT{value: `page`, key: true})
+	captions.addCellStr(`rows`)
+
+	index.addHeaderRow(captions)
+	index.columns = len(captions.cells)
+
+	for number := 1; number <= pages; number++ {
+		from := (number - 1) * size
+		to := min(from+size, length)
+
+		err := writeFile(filepath.Join(doc.dir, pageFile(index.id, number)), func(file *os.File) error {
+			pageDoc := newHTMLDocument(file, doc.dumper)
+			nav := pageNav(index.id, number, pages)
+
+			pageDoc.add(nav)
+
+			table := pageDoc.newTable()
+			table.paged = true
+			table.Caption(fmt.Sprintf(`%s — rows %s`, caption, rowRange(from, to)))
+
+			page(table, from, to)
+			table.end()
+
+			pageDoc.add(nav)
+			pageDoc.end()
+
+			return pageDoc.save()
+		})
+		if err != nil {
+			return err
+		}
+
+		var row rowT
+
+		row.addCell(cellT{value: fmt.Sprintf(`page %d`, number), href: pageFile(index.id, number), key: true})
+		row.addCellStr(rowRange(from, to))
+
+		index.addBodyRow(row)
+	}
+
+	index.write(doc)
+
+	return nil
+}
+
+// pageFile returns the file name of the page of the table.
+func pageFile(tableID string, number int) string {
+	return fmt.Sprintf(`%s-%04d.html`, tableID, number)
+}
+
+// rowRange returns the numbers of the first and the last row, counted from one.
+func rowRange(from, to int) string {
+	return formatCount(from+1) + `–` + formatCount(to)
+}
+
+// pageNav returns the navigation between the pages and back to the index.
+func pageNav(tableID string, number, pages int) string {
+	link := func(label string, target int) string {
+		if target < 1 || target > pages {
+			return `<span class="disabled">` + label + `</span>`
+		}
+
+		return `<a href="` + escapeAttr(pageFile(tableID, target)) + `">` + label + `</a>`
+	}
+
+	return `<nav class="pages">` +
+		`<a href="` + IndexFile + `">index</a>` +
+		link(`← previous`, number-1) +
+		fmt.Sprintf(`<span>page %d of %d</span>`, number, pages) +
+		link(`next →`, number+1) +
+		`</nav>`
+}
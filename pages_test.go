@@ -0,0 +1,98 @@
+package htmldump_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/oslyak/htmldump"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDumpDir(t *testing.T) {
+	t.Parallel()
+
+	dir := filepath.Join(t.TempDir(), `dump`)
+
+	numbers := make([]int, 25)
+	for idx := range numbers {
+		numbers[idx] = idx * 10
+	}
+
+	names := map[int]string{1: `one`, 2: `two`, 3: `three`}
+
+	dumper := htmldump.NewDumper(htmldump.WithPageSize(10), htmldump.WithMaxRows(5))
+	err := dumper.DumpDir(dir, `title`, numbers, names)
+	require.NoError(t, err)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+
+	var files []string
+	for _, entry := range entries {
+		files = append(files, entry.Name())
+	}
+
+	require.Equal(t, []string{`index.html`, `table-2-0001.html`, `table-2-0002.html`, `table-2-0003.html`}, files)
+
+	index, err := os.ReadFile(filepath.Join(dir, htmldump.IndexFile))
+	require.NoError(t, err)
+
+	tables := removeStyle(t, extractHTMLTables(t, string(index)))
+
+	require.Contains(t, tables, `<caption>[]int (length: 25) — 3 pages</caption>`)
+	require.Contains(t, tables, `<tr><td><a href="table-2-0001.html">page 1</a></td><td>1–10</td></tr>`)
+	require.Contains(t, tables, `<tr><td><a href="table-2-0003.html">page 3</a></td><td>21–25</td></tr>`)
+	require.Contains(t, tables, `<caption>map[int]string (length: 3)</caption>`)
+
+	page, err := os.ReadFile(filepath.Join(dir, `table-2-0002.html`))
+	require.NoError(t, err)
+
+	require.Contains(t, string(page), `<nav class="pages"><a href="index.html">index</a><a href="table-2-0001.html">← previous</a>`+
+		`<span>page 2 of 3</span><a href="table-2-0003.html">next →</a></nav>`)
+
+	table := removeStyle(t, extractHTMLTable(t, string(page)))
+
+	require.Contains(t, table, `<caption>[]int (length: 25) — rows 11–20</caption>`)
+	require.Contains(t, table, `<tr><td>10</td><td>100</td></tr>`)
+	require.Contains(t, table, `<tr><td>19</td><td>190</td></tr>`)
+	require.NotContains(t, table, `more elements`)
+
+	page, err = os.ReadFile(filepath.Join(dir, `table-2-0003.html`))
+	require.NoError(t, err)
+
+	require.Contains(t, string(page), `<span class="disabled">next →</span>`)
+}
+
+func TestDumpDirSliceOfMaps(t *testing.T) {
+	t.Parallel()
+
+	dir := filepath.Join(t.TempDir(), `dump`)
+
+	rows := make([]map[string]string, 30)
+	for idx := range rows {
+		rows[idx] = map[string]string{`name`: `row`}
+	}
+
+	rows[25][`late`] = `LATEVALUE`
+
+	dumper := htmldump.NewDumper(htmldump.WithPageSize(10), htmldump.WithMaxRows(5))
+	err := dumper.DumpDir(dir, rows)
+	require.NoError(t, err)
+
+	page, err := os.ReadFile(filepath.Join(dir, `table-1-0001.html`))
+	require.NoError(t, err)
+
+	table := removeStyle(t, extractHTMLTable(t, string(page)))
+
+	require.NotContains(t, table, `late`)
+
+	page, err = os.ReadFile(filepath.Join(dir, `table-1-0003.html`))
+	require.NoError(t, err)
+
+	table = removeStyle(t, extractHTMLTable(t, string(page)))
+
+	require.Contains(t, table, `<tr><th>index</th><th>late</th><th>name</th></tr>`)
+	require.Contains(t, table, `<tr><td>25</td><td>LATEVALUE</td><td>row</td></tr>`)
+}
@@ -0,0 +1,103 @@
+package htmldump
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// registryVersion counts the changes to the formatter and leaf type registries.
+// Plans compiled before a change are compiled again.
+var registryVersion atomic.Uint64
+
+// typePlanT holds what the Dumper decided about a type, so the registries and
+// method sets are searched once per type rather than once per value.
+type typePlanT struct {
+	version    uint64
+	format     FormatFunc // formatter of the type itself, nil when there is none
+	formatted  bool       // the type, or the type it points to, has a formatter
+	leaf       bool
+	expandable bool
+	bytes      bool
+}
+
+// columnsPlanT holds the columns of a struct element type of slice and map tables.
+type columnsPlanT struct {
+	version uint64
+	columns []*columnT
+}
+
+// plansT caches the plans of a Dumper. A Dumper is safe for concurrent use,
+// so the caches are too.
+type plansT struct {
+	types   sync.Map // reflect.Type -> *typePlanT
+	columns sync.Map // reflect.Type -> *columnsPlanT
+}
+
+// typePlan returns the plan of the type, compiling it on first use.
+func (dumper *Dumper) typePlan(valueType reflect.Type) *typePlanT {
+	version := registryVersion.Load()
+
+	if cached, ok := dumper.plans.types.Load(valueType); ok {
+		if plan := cached.(*typePlanT); plan.version == version {
+			return plan
+		}
+	}
+
+	plan := dumper.compileTypePlan(valueType)
+	plan.version = version
+
+	dumper.plans.types.Store(valueType, plan)
+
+	return plan
+}
+
+func (dumper *Dumper) compileTypePlan(valueType reflect.Type) *typePlanT {
+	plan := &typePlanT{}
+
+	for _, registry := range []*formattersT{&dumper.formatters, &globalFormatters} {
+		if format, ok := registry.find(valueType); ok {
+			plan.format = format
+
+			break
+		}
+	}
+
+	plan.formatted = plan.format != nil
+	if !plan.formatted && valueType.Kind() == reflect.Pointer {
+		plan.formatted = dumper.typePlan(valueType.Elem()).formatted
+	}
+
+	plan.leaf = plan.formatted || dumper.isLeafElem(valueType)
+	plan.expandable = isStructOrPointerToStruct(valueType) && !plan.leaf
+
+	elemType := valueType
+	if elemType.Kind() == reflect.Pointer {
+		elemType = elemType.Elem()
+	}
+
+	switch elemType.Kind() {
+	case reflect.Slice, reflect.Array:
+		plan.bytes = elemType.Elem().Kind() == reflect.Uint8 && !plan.leaf
+	}
+
+	return plan
+}
+
+// structColumnsPlan returns the columns of the struct element type, compiling
+// them on first use. The columns are shared and must not be changed.
+func (dumper *Dumper) structColumnsPlan(structType reflect.Type) []*columnT {
+	version := registryVersion.Load()
+
+	if cached, ok := dumper.plans.columns.Load(structType); ok {
+		if plan := cached.(*columnsPlanT); plan.version == version {
+			return plan.columns
+		}
+	}
+
+	columns := dumper.structColumns(structType, 0, make(map[reflect.Type]bool))
+
+	dumper.plans.columns.Store(structType, &columnsPlanT{version: version, columns: columns})
+
+	return columns
+}
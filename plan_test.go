@@ -0,0 +1,71 @@
+package htmldump_test
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"github.com/oslyak/htmldump"
+
+	"github.com/stretchr/testify/require"
+)
+
+type planPoint struct {
+	X, Y int
+}
+
+type planShape struct {
+	Name   string
+	Center planPoint
+}
+
+func TestPlansFollowRegistration(t *testing.T) {
+	t.Parallel()
+
+	dumper := htmldump.NewDumper()
+	shapes := []planShape{{Name: `dot`, Center: planPoint{X: 1, Y: 2}}}
+
+	buffer := bytes.NewBuffer([]byte{})
+	err := dumper.Dump(buffer, shapes)
+	require.NoError(t, err)
+	require.Contains(t, removeStyle(t, extractHTMLTable(t, buffer.String())), `<tr><td>0</td><td>dot</td><td>1</td><td>2</td></tr>`)
+
+	htmldump.RegisterFormatter(func(point planPoint) string {
+		return `(1, 2)`
+	})
+
+	buffer = bytes.NewBuffer([]byte{})
+	err = dumper.Dump(buffer, shapes)
+	require.NoError(t, err)
+	require.Contains(t, removeStyle(t, extractHTMLTable(t, buffer.String())), `<tr><td>0</td><td>dot</td><td>(1, 2)</td></tr>`)
+}
+
+func TestPlansConcurrentDumps(t *testing.T) {
+	t.Parallel()
+
+	dumper := htmldump.NewDumper()
+	rows := []purchase{{ID: 1, Items: []item{{SKU: `apple`, Count: 2}}}}
+
+	var group sync.WaitGroup
+
+	// Dumps are checked once they are done, require must not stop the test from another goroutine.
+	buffers := make([]bytes.Buffer, 8)
+	errs := make([]error, len(buffers))
+
+	for idx := range buffers {
+		group.Add(1)
+
+		go func() {
+			defer group.Done()
+
+			errs[idx] = dumper.Dump(&buffers[idx], rows, map[string]item{`a`: {SKU: `pear`}})
+		}()
+	}
+
+	group.Wait()
+
+	for idx := range buffers {
+		require.NoError(t, errs[idx])
+		require.Contains(t, buffers[idx].String(), `>apple</td>`)
+	}
+}
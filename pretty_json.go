@@ -0,0 +1,152 @@
+package htmldump
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// jsonNodeT is a JSON value, with the members of objects and the elements
+// of arrays kept in the order they were written.
+type jsonNodeT struct {
+	key      string
+	token    json.Token
+	delim    json.Delim
+	children []jsonNodeT
+}
+
+// prettyJSON returns the text as a collapsible JSON tree of trusted HTML.
+// It reports false when the text is not a JSON object or array.
+func prettyJSON(text string) (string, bool) {
+	trimmed := strings.TrimSpace(text)
+	if !strings.HasPrefix(trimmed, `{`) && !strings.HasPrefix(trimmed, `[`) || !json.Valid([]byte(trimmed)) {
+		return ``, false
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(trimmed))
+	decoder.UseNumber()
+
+	node, err := readJSONNode(decoder)
+	if err != nil {
+		return ``, false
+	}
+
+	var result strings.Builder
+
+	result.WriteString(`<div class="json">`)
+	node.toHTML(&result)
+	result.WriteString(`</div>`)
+
+	return result.String(), true
+}
+
+// readJSONNode reads the next JSON value, with everything inside it.
+func readJSONNode(decoder *json.Decoder) (jsonNodeT, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return jsonNodeT{}, err
+	}
+
+	delim, ok := token.(json.Delim)
+	if !ok {
+		return jsonNodeT{token: token}, nil
+	}
+
+	node := jsonNodeT{delim: delim}
+
+	for decoder.More() {
+		var key string
+
+		if delim == '{' {
+			keyToken, err := decoder.Token()
+			if err != nil {
+				return jsonNodeT{}, err
+			}
+
+			key, _ = keyToken.(string)
+		}
+
+		child, err := readJSONNode(decoder)
+		if err != nil {
+			return jsonNodeT{}, err
+		}
+
+		child.key = key
+		node.children = append(node.children, child)
+	}
+
+	// The closing delimiter.
+	if _, err := decoder.Token(); err != nil && !errors.Is(err, io.EOF) {
+		return jsonNodeT{}, err
+	}
+
+	return node, nil
+}
+
+func (node jsonNodeT) toHTML(result *strings.Builder) {
+	if node.delim == 0 {
+		result.WriteString(jsonScalar(node.token))
+
+		return
+	}
+
+	opening, closing, unit := `[`, `]`, `items`
+	if node.delim == '{' {
+		opening, closing, unit = `{`, `}`, `keys`
+	}
+
+	if len(node.children) == 0 {
+		result.WriteString(opening + closing)
+
+		return
+	}
+
+	fmt.Fprintf(result, `<details open><summary>%s <span class="json-count">%d %s</span></summary><div class="json-children">`,
+		opening, len(node.children), unit)
+
+	for idx, child := range node.children {
+		result.WriteString(`<div>`)
+
+		if node.delim == '{' {
+			result.WriteString(`<span class="json-key">` + escapeText(quoteJSON(child.key)) + `</span>: `)
+		}
+
+		child.toHTML(result)
+
+		if idx < len(node.children)-1 {
+			result.WriteString(`,`)
+		}
+
+		result.WriteString(`</div>`)
+	}
+
+	result.WriteString(`</div>` + closing + `</details>`)
+}
+
+// jsonScalar returns the HTML of a JSON string, number, bool or null.
+func jsonScalar(token json.Token) string {
+	switch value := token.(type) {
+	case string:
+		return `<span class="json-string">` + escapeText(quoteJSON(value)) + `</span>`
+	case json.Number:
+		return `<span class="json-number">` + escapeText(value.String()) + `</span>`
+	case nil:
+		return `<span class="json-literal">null</span>`
+	default:
+		return `<span class="json-literal">` + escapeText(fmt.Sprint(value)) + `</span>`
+	}
+}
+
+// quoteJSON quotes the string as JSON does, without escaping HTML characters.
+func quoteJSON(value string) string {
+	var buffer bytes.Buffer
+
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(value)
+
+	return strings.TrimSuffix(buffer.String(), "\n")
+}
@@ -0,0 +1,262 @@
+package htmldump
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// sqlKeywords are highlighted in SQL strings.
+var sqlKeywords = map[string]bool{
+	`ALL`: true, `ALTER`: true, `AND`: true, `AS`: true, `ASC`: true, `BETWEEN`: true, `BY`: true,
+	`CASE`: true, `CREATE`: true, `CROSS`: true, `DELETE`: true, `DESC`: true, `DISTINCT`: true,
+	`DROP`: true, `ELSE`: true, `END`: true, `EXISTS`: true, `FALSE`: true, `FROM`: true, `FULL`: true,
+	`GROUP`: true, `HAVING`: true, `ILIKE`: true, `IN`: true, `INDEX`: true, `INNER`: true,
+	`INSERT`: true, `INTO`: true, `IS`: true, `JOIN`: true, `LEFT`: true, `LIKE`: true, `LIMIT`: true,
+	`NOT`: true, `NULL`: true, `OFFSET`: true, `ON`: true, `OR`: true, `ORDER`: true, `OUTER`: true,
+	`RETURNING`: true, `RIGHT`: true, `SELECT`: true, `SET`: true, `TABLE`: true, `THEN`: true,
+	`TRUE`: true, `UNION`: true, `UPDATE`: true, `VALUES`: true, `WHEN`: true, `WHERE`: true, `WITH`: true,
+}
+
+// sqlClauses start a new line.
+var sqlClauses = map[string]bool{
+	`DELETE`: true, `FROM`: true, `GROUP`: true, `HAVING`: true, `INSERT`: true, `JOIN`: true,
+	`LIMIT`: true, `OFFSET`: true, `ORDER`: true, `RETURNING`: true, `SELECT`: true, `SET`: true,
+	`UNION`: true, `UPDATE`: true, `VALUES`: true, `WHERE`: true, `WITH`: true,
+}
+
+// sqlJoins start a new line when they begin a join, such as LEFT OUTER JOIN.
+var sqlJoins = map[string]bool{
+	`CROSS`: true, `FULL`: true, `INNER`: true, `LEFT`: true, `OUTER`: true, `RIGHT`: true,
+}
+
+// sqlStatements are the keywords a string must start with to be taken for SQL,
+// with the pattern of tokens that has to follow, so prose such as "Select a
+// file from the list" is not. A pattern token is a keyword, alternatives
+// joined by |, name for an identifier or a subquery, … for any tokens, or a
+// symbol. Whitespace and comments are skipped.
+var sqlStatements = map[string][]string{
+	`ALTER`:  {`…`, `TABLE|INDEX|VIEW`, `name`},
+	`CREATE`: {`…`, `TABLE|INDEX|VIEW`, `name`},
+	`DELETE`: {`FROM`, `name`},
+	`DROP`:   {`…`, `TABLE|INDEX|VIEW`, `name`},
+	`INSERT`: {`INTO`, `name`},
+	`SELECT`: {`…`, `FROM`, `name`},
+	`UPDATE`: {`name`, `SET`, `name`},
+	`WITH`:   {`…`, `name`, `AS`, `(`},
+}
+
+// prettySQL returns the SQL statement with a line per clause and highlighted
+// keywords, as trusted HTML. It reports false when the text is not SQL.
+func prettySQL(text string) (string, bool) {
+	text = strings.TrimSpace(text)
+
+	if !isSQL(text) {
+		return ``, false
+	}
+
+	var (
+		result  strings.Builder
+		space   bool // whitespace before the token
+		join    bool // the previous keyword began a join
+		between bool // the next AND belongs to BETWEEN
+		comment bool // the previous token was a comment, running to the end of its line
+	)
+
+	newline := func(indent string) {
+		if result.Len() > 0 && !strings.HasSuffix(result.String(), "\n") {
+			result.WriteString("\n" + indent)
+		}
+	}
+
+	for len(text) > 0 {
+		token, rest := nextSQLToken(text)
+		text = rest
+
+		first, _ := utf8.DecodeRuneInString(token)
+
+		switch {
+		case unicode.IsSpace(first):
+			if comment {
+				newline(``)
+			} else {
+				space = true
+			}
+
+			continue
+		case strings.HasPrefix(token, `--`):
+			if space {
+				result.WriteString(` `)
+			}
+
+			result.WriteString(`<span class="sql-comment">` + escapeText(token) + `</span>`)
+		case strings.HasPrefix(token, `'`):
+			if space {
+				result.WriteString(` `)
+			}
+
+			result.WriteString(`<span class="sql-string">` + escapeText(token) + `</span>`)
+		case sqlKeywords[strings.ToUpper(token)]:
+			keyword := strings.ToUpper(token)
+
+			switch {
+			case keyword == `JOIN` && join:
+				result.WriteString(` `)
+			case sqlClauses[keyword], sqlJoins[keyword] && !join:
+				newline(``)
+			case (keyword == `AND` || keyword == `OR`) && !between:
+				newline(`  `)
+			case space:
+				result.WriteString(` `)
+			}
+
+			if keyword == `AND` {
+				between = false
+			}
+
+			if keyword == `BETWEEN` {
+				between = true
+			}
+
+			join = sqlJoins[keyword]
+
+			result.WriteString(`<span class="sql-keyword">` + escapeText(token) + `</span>`)
+		default:
+			if space {
+				result.WriteString(` `)
+			}
+
+			result.WriteString(escapeText(token))
+		}
+
+		if !sqlKeywords[strings.ToUpper(token)] {
+			join = false
+		}
+
+		comment = strings.HasPrefix(token, `--`)
+		space = false
+	}
+
+	return `<pre class="sql">` + result.String() + `</pre>`, true
+}
+
+// isSQL reports whether the text starts with a statement keyword followed by
+// the pattern of the statement. Keywords are all upper or all lower case, the
+// same case as the statement keyword, as prose starts with a capital.
+func isSQL(text string) bool {
+	first, text := nextSQLToken(text)
+
+	upper := first == strings.ToUpper(first)
+	if !upper && first != strings.ToLower(first) {
+		return false
+	}
+
+	pattern, ok := sqlStatements[strings.ToUpper(first)]
+	if !ok {
+		return false
+	}
+
+	var tokens []string
+
+	for len(text) > 0 {
+		token, rest := nextSQLToken(text)
+		text = rest
+
+		char, _ := utf8.DecodeRuneInString(token)
+		if !unicode.IsSpace(char) && !strings.HasPrefix(token, `--`) {
+			tokens = append(tokens, token)
+		}
+	}
+
+	return matchSQL(tokens, pattern, upper)
+}
+
+// matchSQL reports whether the tokens start with the pattern.
+func matchSQL(tokens, pattern []string, upper bool) bool {
+	switch {
+	case len(pattern) == 0:
+		return true
+	case pattern[0] == `…`:
+		for idx := range len(tokens) + 1 {
+			if matchSQL(tokens[idx:], pattern[1:], upper) {
+				return true
+			}
+		}
+
+		return false
+	case len(tokens) == 0 || !matchSQLToken(tokens[0], pattern[0], upper):
+		return false
+	default:
+		return matchSQL(tokens[1:], pattern[1:], upper)
+	}
+}
+
+// matchSQLToken reports whether the token matches the pattern token.
+func matchSQLToken(token, expected string, upper bool) bool {
+	if expected == `name` {
+		first, _ := utf8.DecodeRuneInString(token)
+
+		return token == `(` || first == '"' || first == '`' ||
+			isSQLWordRune(first) && !sqlKeywords[strings.ToUpper(token)]
+	}
+
+	for _, keyword := range strings.Split(expected, `|`) {
+		if !upper {
+			keyword = strings.ToLower(keyword)
+		}
+
+		if token == keyword {
+			return true
+		}
+	}
+
+	return false
+}
+
+// nextSQLToken splits the text into its first token and the rest. Tokens are
+// whitespace, words, quoted strings and identifiers, comments and single symbols.
+func nextSQLToken(text string) (string, string) {
+	first, size := utf8.DecodeRuneInString(text)
+
+	end := size
+
+	switch {
+	case unicode.IsSpace(first):
+		end = len(text) - len(strings.TrimLeftFunc(text, unicode.IsSpace))
+	case isSQLWordRune(first):
+		end = len(text) - len(strings.TrimLeftFunc(text, isSQLWordRune))
+	case strings.HasPrefix(text, `--`):
+		end = strings.IndexByte(text, '\n')
+		if end < 0 {
+			end = len(text)
+		}
+	case first == '\'' || first == '"' || first == '`':
+		end = quotedEnd(text, byte(first))
+	}
+
+	return text[:end], text[end:]
+}
+
+// quotedEnd returns the end of the quoted string at the start of the text,
+// doubled quotes are escaped ones.
+func quotedEnd(text string, quote byte) int {
+	for idx := 1; idx < len(text); idx++ {
+		if text[idx] != quote {
+			continue
+		}
+
+		if idx+1 < len(text) && text[idx+1] == quote {
+			idx++
+
+			continue
+		}
+
+		return idx + 1
+	}
+
+	return len(text)
+}
+
+func isSQLWordRune(char rune) bool {
+	return unicode.IsLetter(char) || unicode.IsDigit(char) || char == '_' || char == '.' || char == '$'
+}
@@ -0,0 +1,137 @@
+package htmldump
+
+import (
+	"fmt"
+	"time"
+)
+
+// plainReloadScript reloads the page at the interval.
+const plainReloadScript = `  <script>
+    setInterval(function () {
+      location.reload();
+    }, %d);
+  </script>`
+
+// changeReloadScript polls the page at the interval and reloads it only once
+// its content changed. Before reloading it keeps the scroll position, the open
+// nested tables, the shown raw strings, the collapsed tree rows and the table
+// filters in the session storage, and puts them back after the reload.
+// Browsers refuse to fetch file URLs, so pages opened from a file reload at
+// the interval instead, keeping their state the same way.
+const changeReloadScript = `  <script>
+    (function () {
+      const key = 'htmldump-state:' + location.pathname;
+
+      function hash(text) {
+        let hash = 2166136261;
+        for (let idx = 0; idx < text.length; idx++) {
+          hash = Math.imul(hash ^ text.charCodeAt(idx), 16777619);
+        }
+        return hash >>> 0;
+      }
+
+      function indexes(elements, test) {
+        return Array.from(elements).flatMap(function (element, idx) { return test(element) ? [idx] : []; });
+      }
+
+      function save() {
+        sessionStorage.setItem(key, JSON.stringify({
+          x: scrollX,
+          y: scrollY,
+          open: indexes(document.querySelectorAll('details'), function (element) { return element.open; }),
+          raw: indexes(document.querySelectorAll('.raw-toggle'), function (element) { return element.checked; }),
+          collapsed: indexes(document.querySelectorAll('tr[data-branch]'), function (element) {
+            return element.dataset.collapsed !== undefined;
+          }),
+          filters: Array.from(document.querySelectorAll('input.table-filter'), function (element) { return element.value; }),
+        }));
+      }
+
+      function restore() {
+        const saved = sessionStorage.getItem(key);
+        if (!saved) {
+          return;
+        }
+        sessionStorage.removeItem(key);
+
+        const state = JSON.parse(saved);
+        const details = document.querySelectorAll('details');
+        details.forEach(function (element, idx) { element.open = state.open.includes(idx); });
+        const toggles = document.querySelectorAll('.raw-toggle');
+        state.raw.forEach(function (idx) { if (toggles[idx]) { toggles[idx].checked = true; } });
+        const branches = document.querySelectorAll('tr[data-branch]');
+        state.collapsed.forEach(function (idx) { if (branches[idx]) { branches[idx].cells[0].click(); } });
+        const filters = document.querySelectorAll('input.table-filter');
+        state.filters.forEach(function (value, idx) {
+          if (filters[idx] && value) {
+            filters[idx].value = value;
+            filters[idx].dispatchEvent(new Event('input'));
+          }
+        });
+        scrollTo(state.x, state.y);
+      }
+
+      function poll(known) {
+        fetch(location.href, {cache: 'no-store'})
+          .then(function (response) { return response.text(); })
+          .then(function (text) {
+            const current = hash(text);
+            if (known !== undefined && current !== known) {
+              save();
+              location.reload();
+              return;
+            }
+            setTimeout(function () { poll(current); }, %d);
+          })
+          .catch(function () {
+            setTimeout(function () { poll(known); }, %d);
+          });
+      }
+
+      addEventListener('load', function () {
+        restore();
+        if (location.protocol === 'file:') {
+          setTimeout(function () {
+            save();
+            location.reload();
+          }, %d);
+          return;
+        }
+        poll();
+      });
+    })();
+  </script>`
+
+// WithoutAutoReload stops the generated page from reloading itself, for dumps
+// sent to others, archived or served over HTTP.
+func WithoutAutoReload() Option {
+	return WithAutoReload(0)
+}
+
+// WithChangeAwareReload makes the generated page poll its own URL at the
+// interval and reload only when the content changed, keeping the scroll
+// position and the expanded and collapsed parts. Browsers refuse to fetch file
+// URLs, so pages opened from a file, such as the ones of DumpFile, reload at
+// the interval whether they changed or not, still keeping those parts. Like
+// WithAutoReload, zero or a negative interval disables reloading and shorter
+// intervals than MinReloadInterval are raised to it.
+func WithChangeAwareReload(interval time.Duration) Option {
+	return func(dumper *Dumper) {
+		dumper.reloadInterval = interval
+		dumper.reloadOnChange = true
+	}
+}
+
+// reloadScript returns the script reloading the page, or nothing when reloading is disabled.
+func (dumper *Dumper) reloadScript() (string, bool) {
+	milliseconds := max(dumper.reloadInterval, MinReloadInterval).Milliseconds()
+
+	switch {
+	case dumper.reloadInterval <= 0:
+		return ``, false
+	case dumper.reloadOnChange:
+		return fmt.Sprintf(changeReloadScript, milliseconds, milliseconds, milliseconds), true
+	default:
+		return fmt.Sprintf(plainReloadScript, milliseconds), true
+	}
+}
@@ -0,0 +1,38 @@
+package htmldump
+
+import (
+	"reflect"
+)
+
+// scalarToHTML generates a one-row HTML table for a value of any other kind,
+// such as a number or a bool, or for nil.
+func scalarToHTML(doc *htmlDocument, reflectedValue reflect.Value) error {
+	caption := `nil`
+	if reflectedValue.IsValid() {
+		caption = getFieldTypeName(reflectedValue.Type())
+	}
+
+	table := doc.newTable()
+	table.Caption(caption).
+		scalarBody(reflectedValue).
+		write(doc)
+
+	return nil
+}
+
+func (table *tableT) scalarBody(reflectedValue reflect.Value) *tableT {
+	var row rowT
+
+	row.addCell(cellT{value: `Value`, key: true})
+
+	if !reflectedValue.IsValid() || reflectedValue.Kind() == reflect.Pointer && reflectedValue.IsNil() {
+		row.addCell(cellT{value: NULL})
+	} else {
+		row.addCell(table.dumper.valueCell(reflectedValue))
+	}
+
+	table.addBodyRow(row)
+	table.columns = len(row.cells)
+
+	return table
+}
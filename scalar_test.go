@@ -0,0 +1,46 @@
+package htmldump_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/oslyak/htmldump"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDumpAnyKind(t *testing.T) {
+	t.Parallel()
+
+	number := 3.5
+	text := `hello`
+	channel := make(chan int, 4)
+	channel <- 1
+
+	var nilSlice *[]int
+
+	buffer := bytes.NewBuffer([]byte{})
+	err := htmldump.ToHTML(buffer, 42, true, &number, complex(1, 2), nil, nilSlice, &text,
+		[2]string{`a`, `b`}, &[]int{7}, channel, strings.ToUpper, (*item)(nil))
+	require.NoError(t, err)
+
+	tables := removeStyle(t, extractHTMLTables(t, buffer.String()))
+
+	require.Contains(t, tables, `<caption>int</caption><thead></thead><tbody><tr><td>Value</td><td>42</td></tr></tbody>`)
+	require.Contains(t, tables, `<caption>bool</caption><thead></thead><tbody><tr><td>Value</td><td>true</td></tr></tbody>`)
+	require.Contains(t, tables, `<caption>*float64</caption><thead></thead><tbody><tr><td>Value</td><td>3.5</td></tr></tbody>`)
+	require.Contains(t, tables, `<caption>complex128</caption><thead></thead><tbody><tr><td>Value</td><td>(1+2i)</td></tr></tbody>`)
+	require.Contains(t, tables, `<caption>nil</caption><thead></thead><tbody><tr><td>Value</td><td>NULL</td></tr></tbody>`)
+	require.Contains(t, tables, `<caption>*[]int</caption><thead></thead><tbody><tr><td>Value</td><td>NULL</td></tr></tbody>`)
+	require.Contains(t, tables, `<caption>*item</caption><thead></thead><tbody><tr><td>Value</td><td>NULL</td></tr></tbody>`)
+	require.Contains(t, tables, `<tr><td>Value</td><td><pre >hello</pre></td></tr>`)
+	require.Contains(t, tables, `<caption>[2]string (length: 2)</caption>`)
+	require.Contains(t, tables, `<tr><td>0</td><td>a</td></tr><tr><td>1</td><td>b</td></tr>`)
+	require.Contains(t, tables, `<caption>*[]int (length: 1)</caption><thead><tr><th>index</th><th>value</th></tr><tr><th>int</th><th>int</th></tr></thead>`)
+	require.Contains(t, tables, `<caption>chan int</caption><thead></thead><tbody><tr><td>Element type</td><td>int</td></tr>`+
+		`<tr><td>Length</td><td>1</td></tr><tr><td>Capacity</td><td>4</td></tr></tbody>`)
+	require.Contains(t, tables, `<caption>func</caption><thead></thead><tbody><tr><td>Signature</td><td>func(string) string</td></tr>`+
+		`<tr><td>Name</td><td>strings.ToUpper</td></tr><tr><td>Location</td><td>`)
+	require.Regexp(t, `<td>Location</td><td>[^<]+/strings/strings\.go:\d+</td>`, tables)
+}
@@ -4,14 +4,12 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
-	"strings"
-	"unsafe"
 )
 
 // Generate HTML table for a slice, with type and values.
 func sliceToHTML(doc *htmlDocument, reflectedSlice reflect.Value) error {
 	if !isPointerToSliceOrSlice(reflectedSlice) {
-		return fmt.Errorf(`[sliceToHTML] only accepts slice or pointer to it, got %s`, reflectedSlice.Kind())
+		return fmt.Errorf(`[sliceToHTML] only accepts slice, array or pointer to them, got %s`, reflectedSlice.Kind())
 	}
 
 	caption, err := sliceCaption(reflectedSlice)
@@ -19,11 +17,22 @@ func sliceToHTML(doc *htmlDocument, reflectedSlice reflect.Value) error {
 		return err
 	}
 
-	table := new(tableT)
+	length := reflect.Indirect(reflectedSlice).Len()
+
+	if doc.paginates(length) {
+		return doc.writePages(caption, length, func(table *tableT, from, to int) {
+			table.sliceHeader(reflectedSlice, from, to).
+				begin(to-from).
+				sliceRange(reflect.Indirect(reflectedSlice), from, to)
+		})
+	}
+
+	table := doc.newTable()
 	table.Caption(caption).
-		sliceHeader(reflectedSlice).
+		sliceHeader(reflectedSlice, 0, length).
+		begin(length).
 		sliceBody(reflectedSlice).
-		toHTML(doc)
+		end()
 
 	return nil
 }
@@ -35,96 +44,72 @@ func sliceCaption(reflectedSlice reflect.Value) (string, error) {
 		return ``, err
 	}
 
-	return fmt.Sprintf(`%s (length: %d)`, typeName, reflectedSlice.Len()), nil
+	return fmt.Sprintf(`%s (length: %d)`, typeName, reflect.Indirect(reflectedSlice).Len()), nil
 }
 
-// Generate HTML table header for a slice with slice key and slice value types and struct fields.
-func (table *tableT) sliceHeader(reflectedSlice reflect.Value) *tableT {
+// Generate HTML table header for a slice with slice key and slice value types and struct fields,
+// for the elements from the index up to the end index.
+func (table *tableT) sliceHeader(reflectedSlice reflect.Value, from, to int) *tableT {
 	var captions, types rowT
 
-	captions.addCell(cellT{value: `index`, key: true})
-	types.addCell(cellT{value: `int`, key: true})
+	captions.addCell(sortableCell(cellT{value: `index`, key: true}, 0, intType))
+	types.addCell(sortableCell(cellT{value: `int`, key: true}, 0, intType))
+
+	if table.keysHeader(reflectedSlice, from, to, &captions, &types) {
+		return table
+	}
 
-	table.headerRow(reflectedSlice.Type().Elem(), &captions, &types)
+	table.headerRow(reflect.Indirect(reflectedSlice).Type().Elem(), &captions, &types)
 
 	return table
 }
 
 func (table *tableT) sliceBody(reflectedSlice reflect.Value) *tableT {
-	for index := 0; index < reflectedSlice.Len(); index++ {
-		var row rowT
+	return table.sliceRange(reflect.Indirect(reflectedSlice), 0, reflect.Indirect(reflectedSlice).Len())
+}
 
-		row.addCell(cellT{value: strconv.Itoa(index), key: true})
+// sliceRange adds the rows of the elements from the index up to the end index.
+func (table *tableT) sliceRange(reflectedSlice reflect.Value, from, to int) *tableT {
+	for index := from; index < to; index++ {
+		if !table.paged && table.dumper.limits.rowsExceeded(index-from) {
+			table.addTruncatedRow(fmt.Sprintf(`… %s more elements`, formatCount(to-index)))
 
-		item := reflectedSlice.Index(index)
-		if item.Kind() == reflect.Pointer {
-			item = item.Elem()
+			break
 		}
 
-		switch {
-		case !item.IsValid():
-			row.addCell(cellT{value: NULL, colspan: table.columns - 1})
-		case item.Kind() == reflect.Struct:
-			structRow(&row, item)
-		default:
-			row.addCellStr(formatValue(item))
-		}
+		var row rowT
 
+		row.addCell(cellT{value: strconv.Itoa(index), key: true})
+		table.itemCells(&row, reflectedSlice.Index(index))
 		table.addBodyRow(row)
 	}
 
 	return table
 }
 
-func structRow(row *rowT, item reflect.Value) {
-	for idx := 0; idx < item.NumField(); idx++ {
-		field := item.Field(idx)
-		field = reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem()
-
-		if isStructOrPointerToStruct(field.Type()) && !isSkippedType(field.Type()) {
-			if field.Kind() == reflect.Pointer {
-				field = field.Elem()
-			}
-
-			if field.IsValid() {
-				embeddedStructBody(row, field)
-			} else {
-				itemType := item.Type().Field(idx).Type
-				row.addCell(cellT{value: NULL, colspan: structNumbeOfFields(itemType)})
-			}
-
-			continue
-		}
+// itemCells adds the cells of a slice element or a map value to the row.
+func (table *tableT) itemCells(row *rowT, item reflect.Value) {
+	leave, seen, ok := table.enterRow(row, item)
+	if ok {
+		row.addCell(cellT{value: seen.label, link: seen.anchor, colspan: table.columns - 1})
 
-		row.addCellStr(formatValue(field))
+		return
 	}
-}
-
-func embeddedStructBody(row *rowT, embeddedStruct reflect.Value) {
-	for idx := 0; idx < embeddedStruct.NumField(); idx++ {
-		field := embeddedStruct.Field(idx)
-		structField := embeddedStruct.Type().Field(idx)
-
-		if field.Kind() == reflect.Pointer {
-			if field.IsNil() {
-				row.addCellStr(NULL)
-				continue
-			}
-
-			field = field.Elem()
-		}
-
-		if isStructOrPointerToStruct(structField.Type) && !isSkippedType(structField.Type) {
-			if field.IsValid() {
-				row.addCellStr(formatValue(field, `%+v`))
-			} else {
-				row.addCellStr(NULL)
-			}
+	defer leave()
 
-			continue
-		}
+	if item.Kind() == reflect.Pointer {
+		item = item.Elem()
+	}
 
-		row.addCellStr(formatValue(field))
+	switch {
+	case !item.IsValid():
+		row.addCell(cellT{value: NULL, colspan: table.columns - 1})
+	case len(table.fields) > 0 && item.Kind() == reflect.Struct:
+		table.structRow(row, item)
+	case len(table.keys) > 0 && item.Kind() == reflect.Map:
+		table.keysRow(row, item)
+	default:
+		row.addCell(table.valueCell(item, fieldTagT{}, 0))
 	}
 }
 
@@ -136,20 +121,13 @@ func isStructOrPointerToStruct(fieldType reflect.Type) bool {
 	return fieldType.Kind() == reflect.Struct
 }
 
-func isSkippedType(fieldType reflect.Type) bool {
-	const typesToSkipp = `Time NullTime`
-
-	if fieldType.Kind() == reflect.Pointer {
-		fieldType = fieldType.Elem()
-	}
-
-	return strings.Contains(typesToSkipp, fieldType.Name())
-}
-
+// isPointerToSliceOrSlice reports whether the value is a slice, an array,
+// shown the same way, or a pointer to them.
 func isPointerToSliceOrSlice(fieldType reflect.Value) bool {
-	if fieldType.Kind() == reflect.Pointer {
-		return fieldType.Elem().Kind() == reflect.Slice
+	kind := fieldType.Kind()
+	if kind == reflect.Pointer {
+		kind = fieldType.Type().Elem().Kind()
 	}
 
-	return fieldType.Kind() == reflect.Slice
+	return kind == reflect.Slice || kind == reflect.Array
 }
@@ -0,0 +1,106 @@
+package htmldump
+
+import (
+	"reflect"
+	"slices"
+)
+
+// keysHeader adds the header of a slice of maps, such as the []map[string]any
+// decoded from JSON, with a column for every key found in the elements shown,
+// the ones from the index up to the end index within the row limit. Pages show
+// all their elements. It reports false when no element has a key, the slice is
+// then shown as values.
+func (table *tableT) keysHeader(reflectedSlice reflect.Value, from, to int, captions, types *rowT) bool {
+	if reflectedSlice.Kind() == reflect.Pointer {
+		reflectedSlice = reflectedSlice.Elem()
+	}
+
+	mapType := reflectedSlice.Type().Elem()
+	if mapType.Kind() == reflect.Pointer {
+		mapType = mapType.Elem()
+	}
+
+	if mapType.Kind() != reflect.Map {
+		return false
+	}
+
+	if !table.paged && table.dumper.limits.rowsTruncated(to-from) {
+		to = from + table.dumper.limits.MaxRows
+	}
+
+	table.keys = table.dumper.unionOfKeys(reflectedSlice, mapType.Key(), from, to)
+	if len(table.keys) == 0 {
+		return false
+	}
+
+	valueType := getFieldTypeName(mapType.Elem())
+
+	for _, key := range table.keys {
+		column := len(types.cells)
+
+		captions.addCell(sortableCell(cellT{value: table.dumper.formatValue(key)}, column, mapType.Elem()))
+		types.addCell(sortableCell(cellT{value: valueType}, column, mapType.Elem()))
+	}
+
+	table.addHeaderRow(*captions)
+	table.addHeaderRow(*types)
+
+	table.columns = len(types.cells)
+
+	return true
+}
+
+// unionOfKeys returns the keys of the map elements from the index up to the end
+// index, each once, sorted like map keys unless map entries keep Go's order.
+func (dumper *Dumper) unionOfKeys(reflectedSlice reflect.Value, keyType reflect.Type, from, to int) []reflect.Value {
+	var keys []reflect.Value
+
+	found := reflect.MakeMap(reflect.MapOf(keyType, reflect.TypeOf(true)))
+
+	for index := from; index < to; index++ {
+		item := reflectedSlice.Index(index)
+		if item.Kind() == reflect.Pointer {
+			item = item.Elem()
+		}
+
+		if !item.IsValid() {
+			continue
+		}
+
+		iterator := item.MapRange()
+		for iterator.Next() {
+			if found.MapIndex(iterator.Key()).IsValid() {
+				continue
+			}
+
+			found.SetMapIndex(iterator.Key(), reflect.ValueOf(true))
+			keys = append(keys, iterator.Key())
+		}
+	}
+
+	if dumper.mapSort.order == MapOrderSorted {
+		slices.SortStableFunc(keys, compareValues)
+	}
+
+	return keys
+}
+
+// keysRow adds a cell per key column for the map element to the row.
+func (table *tableT) keysRow(row *rowT, item reflect.Value) {
+	if item.IsNil() {
+		row.addCell(cellT{value: NULL, colspan: len(table.keys)})
+
+		return
+	}
+
+	for _, key := range table.keys {
+		value := item.MapIndex(key)
+		if !value.IsValid() {
+			row.addCell(cellT{value: ABSENT, absent: true})
+
+			continue
+		}
+
+		row.addCell(table.valueCell(value, fieldTagT{}, 0))
+	}
+}
@@ -0,0 +1,33 @@
+package htmldump_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/oslyak/htmldump"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDumpSliceOfMaps(t *testing.T) {
+	t.Parallel()
+
+	rows := []map[string]any{
+		{`id`: 1, `name`: `Ann`, `email`: nil},
+		{`id`: 2, `phone`: `555-01`},
+		nil,
+	}
+
+	buffer := bytes.NewBuffer([]byte{})
+	err := htmldump.ToHTML(buffer, rows)
+	require.NoError(t, err)
+
+	table := removeStyle(t, extractHTMLTable(t, buffer.String()))
+
+	require.Contains(t, table, `<thead><tr><th>index</th><th>email</th><th>id</th><th>name</th><th>phone</th></tr>`+
+		`<tr><th>int</th><th>interface {}</th><th>interface {}</th><th>interface {}</th><th>interface {}</th></tr></thead>`)
+	require.Contains(t, table, `<tr><td>0</td><td>NULL</td><td><span >int</span>1</td><td><span >string</span>Ann</td><td>—</td></tr>`)
+	require.Contains(t, table, `<tr><td>1</td><td>—</td><td><span >int</span>2</td><td>—</td><td><span >string</span>555-01</td></tr>`)
+	require.Contains(t, table, `<tr><td>2</td><td colspan="4">NULL</td></tr>`)
+	require.Contains(t, buffer.String(), `<td class="absent">—</td>`)
+}
@@ -92,17 +92,62 @@ func TestDumpSlice(t *testing.T) {
 	table = extractHTMLTable(t, buffer.String())
 	table = removeStyle(t, table)
 
-	expect = `<caption>[]*dump_test.filterAccounts`
+	expect = `<caption>[]*htmldump_test.filterAccounts`
 	require.Contains(t, table, expect)
 
-	expect = `<caption>[]*dump_test.filterAccounts`
+	expect = `<caption>[]*htmldump_test.filterAccounts`
 	require.Contains(t, table, expect)
 
 	strBuilder := strings.Builder{}
-	strBuilder.WriteString(`<tr><th>index</th><th>Name</th><th>OwnerID</th><th>ExchangeID</th>`)
-	strBuilder.WriteString(`<th>today</th><th>yesterdayPtr</th>`)
-	strBuilder.WriteString(`<th>EmptyDatePtr</th><th colspan="5">EmptyPointer(*filter)</th>`)
+	strBuilder.WriteString(`<tr><th  rowspan="2">index</th><th rowspan="2">Name</th><th rowspan="2">OwnerID</th>`)
+	strBuilder.WriteString(`<th rowspan="2">ExchangeID</th><th rowspan="2">today</th><th rowspan="2">yesterdayPtr</th>`)
+	strBuilder.WriteString(`<th rowspan="2">EmptyDatePtr</th><th colspan="5">EmptyPointer(*filter)</th>`)
 	strBuilder.WriteString(`<th colspan="5">EmptyFilter(filter)</th><th colspan="5">filter</th></tr>`)
 
 	require.Contains(t, table, strBuilder.String())
 }
+
+type postalAddress struct {
+	City   string
+	Street string
+}
+
+type customer struct {
+	Name    string
+	Address *postalAddress
+}
+
+type customerOrder struct {
+	ID       int
+	Customer customer
+}
+
+func TestDumpSliceFlattening(t *testing.T) {
+	t.Parallel()
+
+	orders := []customerOrder{
+		{ID: 1, Customer: customer{Name: `Ann`, Address: &postalAddress{City: `Oslo`, Street: `Main`}}},
+		{ID: 2, Customer: customer{Name: `Bob`}},
+	}
+
+	buffer := bytes.NewBuffer([]byte{})
+	err := htmldump.ToHTML(buffer, orders)
+	require.NoError(t, err)
+
+	table := removeStyle(t, extractHTMLTable(t, buffer.String()))
+
+	require.Contains(t, table, `<thead><tr><th  rowspan="2">index</th><th rowspan="2">ID</th><th colspan="3">Customer(customer)</th></tr>`+
+		`<tr><th rowspan="2">Name(string)</th><th colspan="2">Address(*postalAddress)</th></tr>`+
+		`<tr><th>int</th><th>int</th><th>City(string)</th><th>Street(string)</th></tr></thead>`)
+	require.Contains(t, table, `<tr><td>0</td><td>1</td><td>Ann</td><td>Oslo</td><td>Main</td></tr>`)
+	require.Contains(t, table, `<tr><td>1</td><td>2</td><td>Bob</td><td colspan="2">NULL</td></tr>`)
+
+	buffer = bytes.NewBuffer([]byte{})
+	err = htmldump.NewDumper(htmldump.WithFlattenDepth(1)).Dump(buffer, orders)
+	require.NoError(t, err)
+
+	table = removeStyle(t, extractHTMLTable(t, buffer.String()))
+
+	require.Contains(t, table, `<tr><th>int</th><th>int</th><th>Name(string)</th><th>Address(*postalAddress)</th></tr>`)
+	require.Contains(t, table, `<tr><td>0</td><td>1</td><td>Ann</td><td>{City:Oslo Street:Main}</td></tr>`)
+}
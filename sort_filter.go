@@ -0,0 +1,167 @@
+package htmldump
+
+import "reflect"
+
+var intType = reflect.TypeOf(0)
+
+// sortFilterStyle is added to the stylesheet of documents with sortable tables.
+const sortFilterStyle = `    .styled-table th[data-col] {
+        cursor: pointer;
+        user-select: none;
+    }
+    .styled-table th[data-order="asc"]::after {
+        content: " ▲";
+    }
+    .styled-table th[data-order="desc"]::after {
+        content: " ▼";
+    }
+    input.table-filter {
+        display: block;
+        margin: 25px 0 -15px 0;
+        padding: 4px 8px;
+        min-width: 300px;
+        font-size: 0.9em;
+    }
+    .styled-table details > input.table-filter {
+        margin: 4px 0 0 0;
+        min-width: 0;
+    }
+    .styled-table tr[data-filtered] {
+        display: none;
+    }
+`
+
+// sortFilterScript sorts a table by the column of a clicked header, and hides
+// the rows of a table, nested ones too, not matching its filter input. Header
+// cells hold the column they sort by in data-col, body cells the column they
+// start at, so cells spanning several columns are found too. Rows standing in
+// for truncated values stay at the end. Filtered rows are marked with
+// data-filtered rather than hidden, which the tree view uses for collapsed rows.
+const sortFilterScript = `  <script>
+    document.addEventListener('DOMContentLoaded', function () {
+      function cellText(row, col) {
+        for (const cell of row.cells) {
+          const start = Number(cell.dataset.col);
+          if (start <= col && col < start + cell.colSpan) {
+            return cell.textContent.trim();
+          }
+        }
+        return '';
+      }
+
+      function compare(a, b, numeric) {
+        if (numeric) {
+          const x = parseFloat(a), y = parseFloat(b);
+          if (isNaN(x) || isNaN(y)) {
+            return isNaN(x) - isNaN(y);
+          }
+          return x - y;
+        }
+        return a.localeCompare(b, undefined, {numeric: true});
+      }
+
+      function sortTable(header) {
+        const table = header.closest('table');
+        const body = table.tBodies[0];
+        const col = Number(header.dataset.col);
+        const numeric = header.dataset.type === 'number';
+        const order = header.dataset.order === 'asc' ? 'desc' : 'asc';
+        const sign = order === 'asc' ? 1 : -1;
+
+        for (const cell of table.tHead.querySelectorAll('th[data-order]')) {
+          delete cell.dataset.order;
+        }
+        for (const cell of table.tHead.querySelectorAll('th[data-col="' + col + '"]')) {
+          cell.dataset.order = order;
+        }
+
+        const rows = Array.from(body.rows);
+        const sorted = rows.filter(function (row) { return !row.querySelector(':scope > td.truncated'); });
+        const truncated = rows.filter(function (row) { return row.querySelector(':scope > td.truncated'); });
+
+        sorted.sort(function (a, b) {
+          return sign * compare(cellText(a, col), cellText(b, col), numeric);
+        });
+
+        for (const row of sorted.concat(truncated)) {
+          body.appendChild(row);
+        }
+      }
+
+      for (const header of document.querySelectorAll('.styled-table th[data-col]')) {
+        header.addEventListener('click', function () { sortTable(header); });
+      }
+
+      for (const table of document.querySelectorAll('table.styled-table')) {
+        const filter = document.createElement('input');
+        filter.type = 'search';
+        filter.className = 'table-filter';
+        filter.placeholder = 'Filter rows';
+        filter.addEventListener('input', function () {
+          const query = filter.value.toLowerCase();
+          for (const row of table.tBodies[0].rows) {
+            if (query.length > 0 && !row.textContent.toLowerCase().includes(query)) {
+              row.dataset.filtered = '';
+            } else {
+              delete row.dataset.filtered;
+            }
+          }
+        });
+
+        table.parentNode.insertBefore(filter, table);
+      }
+    });
+  </script>`
+
+// WithSortAndFilter embeds a script sorting slice and map tables by the
+// clicked header column, numerically for numeric columns, and adding a filter
+// input above each table, nested tables included, hiding the rows not
+// containing its text. The script is self-contained and works offline.
+func WithSortAndFilter() Option {
+	return func(dumper *Dumper) {
+		dumper.sortFilter = true
+	}
+}
+
+// sortableCell marks the header cell as sorting by the column holding values
+// of the type.
+func sortableCell(cell cellT, column int, valueType reflect.Type) cellT {
+	cell.sortable = true
+	cell.column = column
+	cell.numeric = isNumericType(valueType)
+
+	return cell
+}
+
+func isNumericType(valueType reflect.Type) bool {
+	for valueType.Kind() == reflect.Pointer {
+		valueType = valueType.Elem()
+	}
+
+	switch valueType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// sortable reports whether the cells of the table are numbered for the
+// sorting script, that is when the script is embedded and any header sorts.
+func (table *tableT) sortable() bool {
+	if !table.dumper.sortFilter {
+		return false
+	}
+
+	for _, row := range table.header {
+		for _, cell := range row.cells {
+			if cell.sortable {
+				return true
+			}
+		}
+	}
+
+	return false
+}
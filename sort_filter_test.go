@@ -0,0 +1,55 @@
+package htmldump_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/oslyak/htmldump"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortAndFilter(t *testing.T) {
+	t.Parallel()
+
+	orders := []customerOrder{
+		{ID: 1, Customer: customer{Name: `Ann`, Address: &postalAddress{City: `Oslo`, Street: `Main`}}},
+		{ID: 2, Customer: customer{Name: `Bob`}},
+	}
+
+	buffer := bytes.NewBuffer([]byte{})
+	err := htmldump.NewDumper(htmldump.WithSortAndFilter()).Dump(buffer, orders)
+	require.NoError(t, err)
+
+	require.Contains(t, buffer.String(), `<script>`)
+	require.Contains(t, buffer.String(), `table-filter`)
+	require.Contains(t, buffer.String(), `document.querySelectorAll('table.styled-table')) {
+        const filter`)
+
+	table := removeStyle(t, extractHTMLTable(t, buffer.String()))
+
+	require.Contains(t, table, `<thead><tr><th data-col="0" data-type="number"  rowspan="2">index</th>`+
+		`<th data-col="1" data-type="number" rowspan="2">ID</th><th colspan="3">Customer(customer)</th></tr>`+
+		`<tr><th data-col="2" rowspan="2">Name(string)</th><th colspan="2">Address(*postalAddress)</th></tr>`+
+		`<tr><th data-col="0" data-type="number" >int</th><th data-col="1" data-type="number">int</th>`+
+		`<th data-col="3">City(string)</th><th data-col="4">Street(string)</th></tr></thead>`)
+	require.Contains(t, table, `<tr><td data-col="0" >0</td><td data-col="1">1</td><td data-col="2">Ann</td>`+
+		`<td data-col="3">Oslo</td><td data-col="4">Main</td></tr>`)
+	require.Contains(t, table, `<td data-col="3" colspan="2">NULL</td>`)
+
+	buffer = bytes.NewBuffer([]byte{})
+	err = htmldump.NewDumper(htmldump.WithSortAndFilter()).Dump(buffer, map[string]float64{`a`: 1.5})
+	require.NoError(t, err)
+
+	table = removeStyle(t, extractHTMLTable(t, buffer.String()))
+
+	require.Contains(t, table, `<tr><th data-col="0" >map key</th><th data-col="1" data-type="number">value</th></tr>`+
+		`<tr><th data-col="0" >string</th><th data-col="1" data-type="number">float64</th></tr>`)
+	require.Contains(t, table, `<tr><td data-col="0" >a</td><td data-col="1">1.5</td></tr>`)
+
+	buffer = bytes.NewBuffer([]byte{})
+	err = htmldump.ToHTML(buffer, orders)
+	require.NoError(t, err)
+
+	require.NotContains(t, buffer.String(), `table-filter`)
+	require.NotContains(t, buffer.String(), `data-col`)
+}
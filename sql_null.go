@@ -0,0 +1,38 @@
+package htmldump
+
+import (
+	"database/sql/driver"
+	"reflect"
+)
+
+var valuerType = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+
+// isValuer reports whether values of the type implement driver.Valuer, like
+// sql.NullString, sql.NullInt64, sql.Null[T] and the rest of the family.
+func isValuer(valueType reflect.Type) bool {
+	return valueType.Implements(valuerType) || reflect.PointerTo(valueType).Implements(valuerType)
+}
+
+// driverValue returns the value a driver.Valuer stores in the database,
+// an invalid reflect.Value stands for NULL.
+func driverValue(value reflect.Value) (reflect.Value, bool, error) {
+	if !value.CanInterface() {
+		return reflect.Value{}, false, nil
+	}
+
+	valuer, ok := value.Interface().(driver.Valuer)
+	if !ok && value.CanAddr() {
+		valuer, ok = value.Addr().Interface().(driver.Valuer)
+	}
+
+	if !ok {
+		return reflect.Value{}, false, nil
+	}
+
+	stored, err := valuer.Value()
+	if err != nil {
+		return reflect.Value{}, true, err
+	}
+
+	return reflect.ValueOf(stored), true, nil
+}
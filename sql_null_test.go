@@ -0,0 +1,59 @@
+package htmldump_test
+
+import (
+	"bytes"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/oslyak/htmldump"
+
+	"github.com/stretchr/testify/require"
+)
+
+type userRecord struct {
+	Name     sql.NullString
+	Age      sql.NullInt64
+	Rank     sql.NullInt32
+	Floor    sql.NullInt16
+	Level    sql.NullByte
+	Score    sql.NullFloat64
+	Active   sql.NullBool
+	Deleted  sql.NullTime
+	Manager  sql.Null[int]
+	Nickname *sql.NullString
+}
+
+func TestSQLNullTypes(t *testing.T) {
+	t.Parallel()
+
+	valid := userRecord{
+		Name:     sql.NullString{String: `Ann`, Valid: true},
+		Age:      sql.NullInt64{Int64: 42, Valid: true},
+		Rank:     sql.NullInt32{Int32: 3, Valid: true},
+		Floor:    sql.NullInt16{Int16: 12, Valid: true},
+		Level:    sql.NullByte{Byte: 7, Valid: true},
+		Score:    sql.NullFloat64{Float64: 9.5, Valid: true},
+		Active:   sql.NullBool{Bool: true, Valid: true},
+		Deleted:  sql.NullTime{Time: time.Date(2024, 5, 17, 10, 0, 0, 0, time.UTC), Valid: true},
+		Manager:  sql.Null[int]{V: 5, Valid: true},
+		Nickname: &sql.NullString{String: `annie`, Valid: true},
+	}
+
+	buffer := bytes.NewBuffer([]byte{})
+	err := htmldump.ToHTML(buffer, valid, []userRecord{valid, {}})
+	require.NoError(t, err)
+
+	tables := removeStyle(t, extractHTMLTables(t, buffer.String()))
+
+	require.NotContains(t, tables, `Valid`)
+	require.Contains(t, tables, `<tr><td>Name</td><td>NullString</td><td>Ann</td></tr>`)
+	require.Contains(t, tables, `<tr><td>Deleted</td><td>NullTime</td><td>17.05.2024 10:00:00</td></tr>`)
+	require.Contains(t, tables, `<tr><td>Manager</td><td>Null[int]</td><td>5</td></tr>`)
+	require.Contains(t, tables, `<tr><td>Nickname</td><td>*NullString</td><td>annie</td></tr>`)
+
+	require.Contains(t, tables, `<tr><td>0</td><td>Ann</td><td>42</td><td>3</td><td>12</td><td>7</td><td>9.5</td><td>true</td>`+
+		`<td>17.05.2024 10:00:00</td><td>5</td><td>annie</td></tr>`)
+	require.Contains(t, tables, `<tr><td>1</td><td>NULL</td><td>NULL</td><td>NULL</td><td>NULL</td><td>NULL</td><td>NULL</td><td>NULL</td>`+
+		`<td>NULL</td><td>NULL</td><td></td></tr>`)
+}
@@ -19,21 +19,61 @@ func stringToHTML(doc *htmlDocument, reflectedString reflect.Value) error {
 		return fmt.Errorf(`[stringToHTML] only accepts string, got %s`, reflectedString.Kind())
 	}
 
-	table := new(tableT)
+	table := doc.newTable()
 	table.Caption(fmt.Sprintf("String (length: %d) ", len(reflectedString.String()))).
 		stringBody(reflectedString).
-		toHTML(doc)
+		write(doc)
 
 	return nil
 }
 
 func (table *tableT) stringBody(reflectedString reflect.Value) *tableT {
-	str := reflectedString.String()
 	var row rowT
 
 	row.addCell(cellT{value: "Value", key: true})
-	row.addCell(cellT{value: str})
+
+	if isTrustedHTML(reflectedString) || table.dumper.hasFormatter(reflectedString.Type()) {
+		row.addCell(table.dumper.valueCell(reflectedString))
+	} else {
+		row.addCell(table.textCell(reflectedString.String()))
+	}
+
 	table.addBodyRow(row)
 
 	return table
 }
+
+// textCell returns the cell for a string shown on its own, with whitespace kept.
+// JSON and SQL are formatted, with a toggle showing the raw string instead.
+func (table *tableT) textCell(text string) cellT {
+	cell := cellT{value: text}
+
+	// A cut string is no longer valid JSON or SQL.
+	if table.dumper.limits.truncateValue(&cell) {
+		table.truncated = true
+
+		return cellT{value: `<pre class="text">` + escapeText(cell.value) + `</pre>`, html: true, text: cell.value}
+	}
+
+	raw := `<pre class="text">` + escapeText(text) + `</pre>`
+
+	formatted, ok := prettyJSON(text)
+	if !ok {
+		formatted, ok = prettySQL(text)
+	}
+
+	if !ok {
+		return cellT{value: raw, html: true, text: text}
+	}
+
+	toggle := table.id + `-raw`
+
+	return cellT{
+		value: `<input type="checkbox" class="raw-toggle" id="` + toggle + `">` +
+			`<label for="` + toggle + `">raw</label>` +
+			`<div class="formatted">` + formatted + `</div>` +
+			`<div class="raw">` + raw + `</div>`,
+		html: true,
+		text: text,
+	}
+}
@@ -0,0 +1,84 @@
+package htmldump_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/oslyak/htmldump"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDumpStringViews(t *testing.T) {
+	t.Parallel()
+
+	buffer := bytes.NewBuffer([]byte{})
+	err := htmldump.ToHTML(buffer,
+		"first line\n  indented <line>",
+		`{"name": "wolf", "tags": ["grey", 1.5, true, null], "empty": {}}`,
+		"SELECT Name, Age FROM animals a LEFT JOIN zoos z ON z.id = a.zoo -- zoo\nWHERE Species = 'wolf' AND Age BETWEEN 1 AND 5 OR Fur ORDER BY Name",
+	)
+	require.NoError(t, err)
+
+	tables := extractHTMLTables(t, buffer.String())
+
+	require.Contains(t, tables, `<td><pre class="text">first line`+"\n"+`  indented &lt;line&gt;</pre></td>`)
+
+	require.Contains(t, tables, `<input type="checkbox" class="raw-toggle" id="table-2-raw"><label for="table-2-raw">raw</label>`)
+	require.Contains(t, tables, `<div class="formatted"><div class="json"><details open><summary>{ <span class="json-count">3 keys</span></summary>`+
+		`<div class="json-children"><div><span class="json-key">&#34;name&#34;</span>: <span class="json-string">&#34;wolf&#34;</span>,</div>`+
+		`<div><span class="json-key">&#34;tags&#34;</span>: <details open><summary>[ <span class="json-count">4 items</span></summary>`+
+		`<div class="json-children"><div><span class="json-string">&#34;grey&#34;</span>,</div><div><span class="json-number">1.5</span>,</div>`+
+		`<div><span class="json-literal">true</span>,</div><div><span class="json-literal">null</span></div></div>]</details>,</div>`+
+		`<div><span class="json-key">&#34;empty&#34;</span>: {}</div></div>}</details></div></div>`)
+	require.Contains(t, tables, `<div class="raw"><pre class="text">{&#34;name&#34;: &#34;wolf&#34;`)
+
+	// The SQL is checked in the document, extracting tables drops whitespace between tags.
+	require.Contains(t, buffer.String(), `<pre class="sql"><span class="sql-keyword">SELECT</span> Name, Age`+"\n"+
+		`<span class="sql-keyword">FROM</span> animals a`+"\n"+
+		`<span class="sql-keyword">LEFT</span> <span class="sql-keyword">JOIN</span> zoos z <span class="sql-keyword">ON</span> z.id = a.zoo <span class="sql-comment">-- zoo</span>`+"\n"+
+		`<span class="sql-keyword">WHERE</span> Species = <span class="sql-string">&#39;wolf&#39;</span>`+"\n"+
+		`  <span class="sql-keyword">AND</span> Age <span class="sql-keyword">BETWEEN</span> 1 <span class="sql-keyword">AND</span> 5`+"\n"+
+		`  <span class="sql-keyword">OR</span> Fur`+"\n"+
+		`<span class="sql-keyword">ORDER</span> <span class="sql-keyword">BY</span> Name</pre>`)
+}
+
+func TestDumpStringNotSQL(t *testing.T) {
+	t.Parallel()
+
+	buffer := bytes.NewBuffer([]byte{})
+	err := htmldump.ToHTML(buffer,
+		`Update available`,
+		`With love`,
+		`Select a file`,
+		`Delete me`,
+		`Select a file from the list`,
+		`Update your profile, then set a password`,
+		`Delete it from disk`,
+		`With love, as (always) yours`,
+		`delete it from disk`,
+		`update your profile, then set a password`,
+		`with love, as always`,
+	)
+	require.NoError(t, err)
+
+	require.NotContains(t, buffer.String(), `<pre class="sql">`)
+
+	buffer = bytes.NewBuffer([]byte{})
+	err = htmldump.ToHTML(buffer, `WITH recent AS (SELECT id FROM users) UPDATE users SET active = true`)
+	require.NoError(t, err)
+
+	require.Contains(t, buffer.String(), `<pre class="sql"><span class="sql-keyword">WITH</span> recent <span class="sql-keyword">AS</span> (`)
+
+	buffer = bytes.NewBuffer([]byte{})
+	err = htmldump.ToHTML(buffer,
+		`select * from (select id from users) u`,
+		`DELETE FROM "users" WHERE id = 1`,
+		`INSERT INTO users (id) VALUES (1)`,
+		`CREATE UNIQUE INDEX users_id ON users (id)`,
+	)
+	require.NoError(t, err)
+
+	require.Equal(t, 4, strings.Count(buffer.String(), `<pre class="sql">`))
+}
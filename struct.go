@@ -3,6 +3,7 @@ package htmldump
 import (
 	"fmt"
 	"reflect"
+	"strconv"
 )
 
 func structToHTML(doc *htmlDocument, input interface{}) error {
@@ -11,24 +12,29 @@ func structToHTML(doc *htmlDocument, input interface{}) error {
 		return fmt.Errorf(`[structToHTML] only accepts struct or pointer to struct, got %s`, structType.Kind())
 	}
 
-	var caption string
+	table := doc.newTable().
+		Caption(structCaption(structType)).
+		structHeader()
 
-	if structType.Kind() == reflect.Pointer {
-		caption = `*struct ` + structType.Elem().Name()
-	} else {
-		caption = `struct ` + structType.Name()
+	if structType.Kind() == reflect.Pointer && !reflect.ValueOf(input).IsNil() {
+		table.visit(reflect.ValueOf(input), visitT{anchor: table.id, label: `↺ see top of table`})
 	}
 
-	table := new(tableT).
-		Caption(caption).
-		structHeader().
-		structBody(input, 0)
+	table.structBody(input, 0)
 
-	table.toHTML(doc)
+	table.write(doc)
 
 	return nil
 }
 
+func structCaption(structType reflect.Type) string {
+	if structType.Kind() == reflect.Pointer {
+		return `*struct ` + structType.Elem().Name()
+	}
+
+	return `struct ` + structType.Name()
+}
+
 func (table *tableT) structHeader() *tableT {
 	row := new(rowT)
 	row.cells = append(row.cells, cellT{value: `Field`})
@@ -36,6 +42,7 @@ func (table *tableT) structHeader() *tableT {
 	row.cells = append(row.cells, cellT{value: `Value`})
 
 	table.addHeaderRow(*row)
+	table.columns = len(row.cells)
 
 	return table
 }
@@ -48,27 +55,80 @@ func (table *tableT) structBody(input interface{}, level int) *tableT {
 	structValue := reflect.ValueOf(pointer).Elem()
 
 	for idx := 0; idx < structType.NumField(); idx++ {
-		row := new(rowT)
+		if table.full || table.dumper.limits.rowsExceeded(table.rows) {
+			if !table.full {
+				table.addTruncatedRow(`… remaining fields not shown`)
+				table.full = true
+			}
+
+			return table
+		}
 
 		structField := structType.Field(idx)
-		fieldName := structField.Name
+		tag := parseFieldTag(structField)
+
+		if tag.skip {
+			continue
+		}
+
+		row := new(rowT)
+		if table.dumper.treeView {
+			row.level = level
+		}
+
+		fieldName := tag.label(structField)
 		fieldTypeName := getFieldTypeName(structField.Type)
 		fieldValue := getUnexportedField(structValue.Field(idx))
 
 		nameStyle := styleT{paddingLeft: spacer * level}
 		style := styleT{}
 
-		if isStructOrPointerToStruct(fieldValue.Type()) && !isSkippedType(fieldValue.Type()) {
+		if table.dumper.expands(fieldValue.Type(), tag) && tag.inline && table.inlined(fieldValue) {
+			table.structBody(fieldValue.Interface(), level)
+
+			continue
+		}
+
+		if table.dumper.expands(fieldValue.Type(), tag) {
 			style.background = getBackground(level)
 			nameStyle.background = style.background
 
 			row.addCellStr(fieldName, nameStyle).
-				addCellStr(fieldTypeName, style).
-				addCellStr(structFieldValue(structField, fieldValue), style)
+				addCellStr(fieldTypeName, style)
+
+			if seen, ok := table.seen(fieldValue); ok {
+				row.addCell(cellT{value: seen.label, link: seen.anchor, styleT: style})
+				table.addBodyRow(*row)
+
+				continue
+			}
+
+			expand := fieldValue.IsValid() && !fieldValue.IsZero()
+
+			if expand && table.dumper.limits.depthExceeded(level+1) {
+				cell := truncatedCell(`… depth limit reached`, 0)
+				cell.styleT = style
+
+				row.addCell(cell)
+				table.addBodyRow(*row)
+				table.truncated = true
+
+				continue
+			}
+
+			row.addCellStr(structFieldValue(structField, fieldValue), style)
+			row.branch = expand && table.dumper.treeView
+
+			if fieldValue.Kind() == reflect.Pointer && !fieldValue.IsNil() {
+				number := table.rows + 1
+				row.id = fmt.Sprintf(`%s-row-%d`, table.id, number)
+
+				table.visit(fieldValue, visitT{anchor: row.id, label: fmt.Sprintf(`↺ see row %d`, number)})
+			}
 
 			table.addBodyRow(*row)
 
-			if fieldValue.IsValid() && !fieldValue.IsZero() {
+			if expand {
 				table.structBody(fieldValue.Interface(), level+1)
 			}
 
@@ -77,9 +137,12 @@ func (table *tableT) structBody(input interface{}, level int) *tableT {
 
 		style.background = getBackground(level)
 		nameStyle.background = style.background
+		cell := table.valueCell(fieldValue, tag, level)
+		cell.styleT = style
+
 		row.addCellStr(fieldName, nameStyle).
 			addCellStr(fieldTypeName, style).
-			addCellStr(formatValue(fieldValue), style)
+			addCell(cell)
 
 		table.addBodyRow(*row)
 	}
@@ -87,6 +150,46 @@ func (table *tableT) structBody(input interface{}, level int) *tableT {
 	return table
 }
 
+// visit remembers where the struct behind a non-nil pointer was rendered.
+func (table *tableT) visit(pointer reflect.Value, visit visitT) {
+	if pointer.Kind() != reflect.Pointer || pointer.IsNil() {
+		return
+	}
+
+	if table.visited == nil {
+		table.visited = make(map[visitKey]visitT)
+	}
+
+	table.visited[visitKey{address: pointer.Pointer(), valueType: pointer.Type()}] = visit
+}
+
+// inlined reports whether the struct field can be shown inline, as the fields
+// of its parent. Nil and already rendered pointers are shown as regular rows.
+func (table *tableT) inlined(fieldValue reflect.Value) bool {
+	if fieldValue.Kind() != reflect.Pointer {
+		return true
+	}
+
+	if _, ok := table.seen(fieldValue); ok || fieldValue.IsNil() {
+		return false
+	}
+
+	table.visit(fieldValue, visitT{label: `↺ shown inline above`})
+
+	return true
+}
+
+// seen returns the place where the struct behind the pointer was already rendered.
+func (table *tableT) seen(pointer reflect.Value) (visitT, bool) {
+	if pointer.Kind() != reflect.Pointer || pointer.IsNil() {
+		return visitT{}, false
+	}
+
+	visit, ok := table.visited[visitKey{address: pointer.Pointer(), valueType: pointer.Type()}]
+
+	return visit, ok
+}
+
 func structFieldValue(structField reflect.StructField, fieldValue reflect.Value) string {
 	switch {
 	case structField.Type.Kind() == reflect.Pointer && fieldValue.IsNil():
@@ -98,13 +201,12 @@ func structFieldValue(structField reflect.StructField, fieldValue reflect.Value)
 	}
 }
 
+// getBackground returns the background of nested fields at the level, the
+// theme sets the colour of each level.
 func getBackground(level int) string {
-	gradient := []string{``, `#AFFFFF`, `#6DEFFF`, `#47D3FF`, `#00B7EB`}
-	index := level
-
-	if index > len(gradient)-1 {
-		index = len(gradient) - 1
+	if level == 0 {
+		return ``
 	}
 
-	return gradient[index]
+	return `var(--level-` + strconv.Itoa(min(level, gradientLevels)) + `)`
 }
@@ -45,3 +45,30 @@ func TestDumpStruct(t *testing.T) {
 
 	require.Contains(t, table, strBuilder.String())
 }
+
+type node struct {
+	Value int
+	Prev  *node
+	Next  *node
+}
+
+func TestDumpStructCycles(t *testing.T) {
+	t.Parallel()
+
+	first := &node{Value: 1}
+	second := &node{Value: 2, Prev: first}
+	third := &node{Value: 3, Prev: second}
+	first.Next = second
+	second.Next = third
+
+	buffer := bytes.NewBuffer([]byte{})
+	err := htmldump.ToHTML(buffer, first)
+	require.NoError(t, err)
+
+	table := extractHTMLTable(t, buffer.String())
+
+	require.Contains(t, table, `<table class="styled-table" id="table-1">`)
+	require.Contains(t, table, `<tr id="table-1-row-3">`)
+	require.Contains(t, table, `<a href="#table-1">↺ see top of table</a>`)
+	require.Contains(t, table, `<a href="#table-1-row-3">↺ see row 3</a>`)
+}
@@ -2,9 +2,9 @@ package htmldump
 
 import (
 	"fmt"
+	"io"
 	"reflect"
 	"strconv"
-	"strings"
 )
 
 type styleT struct {
@@ -29,12 +29,12 @@ func (style *styleT) toHTML() string {
 	}
 
 	if len(style.background) > 0 {
-		html += `background: ` + style.background + `;`
+		html += `background: ` + escapeAttr(style.background) + `;`
 		empty = false
 	}
 
 	if len(style.custom) > 0 {
-		html += style.custom
+		html += escapeAttr(style.custom)
 		empty = false
 	}
 
@@ -48,47 +48,153 @@ func (style *styleT) toHTML() string {
 }
 
 type rowT struct {
+	id    string
 	cells []cellT
+	// level is the nesting level of a struct field row in the tree view,
+	// branch rows are followed by the fields of their struct.
+	level  int
+	branch bool
 }
 
 type cellT struct {
 	colspan int
+	rowspan int
 	key     bool
 	value   string
+	html    bool   // value is trusted HTML and is written without escaping
+	text    string // plain text of a trusted HTML value, for the text formats
+	title   string // name of a header group without its type, for the text formats
+	link    string // id of the element the value links to
+	href    string // URL the value links to, such as another page
+	nested  *tableT
+	// truncated marks the cell standing in for values left out by the limits.
+	truncated bool
+	// absent marks the cell of a key missing from a map element.
+	absent bool
+	// dynamicType is the type of the value held by an interface, shown as a badge.
+	dynamicType string
+	// sortable header cells sort the table by their column, numerically
+	// when the column holds numbers.
+	sortable bool
+	column   int
+	numeric  bool
 	styleT
 }
 
-func (cell *cellT) toHTML(tag string) string {
-	var result strings.Builder
+// htmlWriter is where markup is rendered to: the buffered writer of the
+// document, or a strings.Builder. Write errors are kept by the writer and
+// reported once the document is saved.
+type htmlWriter interface {
+	io.Writer
+	io.StringWriter
+}
 
-	style := cell.styleT.toHTML()
+func (cell *cellT) writeHTML(writer htmlWriter, tag string, column int) {
+	writer.WriteString(`<` + tag)
 
-	result.WriteString(`<` + tag)
+	if column >= 0 {
+		writer.WriteString(` data-col="` + strconv.Itoa(column) + `"`)
 
-	if cell.key {
-		result.WriteString(` class="key"`)
+		if cell.numeric {
+			writer.WriteString(` data-type="number"`)
+		}
+	}
+
+	switch {
+	case cell.key:
+		writer.WriteString(` class="key"`)
+	case cell.truncated:
+		writer.WriteString(` class="truncated"`)
+	case cell.absent:
+		writer.WriteString(` class="absent"`)
 	}
 
 	if cell.colspan > 1 {
-		result.WriteString(` colspan="` + strconv.Itoa(cell.colspan) + `"`)
+		writer.WriteString(` colspan="` + strconv.Itoa(cell.colspan) + `"`)
+	}
+
+	if cell.rowspan > 1 {
+		writer.WriteString(` rowspan="` + strconv.Itoa(cell.rowspan) + `"`)
+	}
+
+	writer.WriteString(cell.styleT.toHTML())
+	writer.WriteString(`>`)
+
+	if len(cell.dynamicType) > 0 {
+		writer.WriteString(`<span class="dynamic-type">` + escapeText(cell.dynamicType) + `</span>`)
 	}
 
-	result.WriteString(style)
-	result.WriteString(`>`)
-	result.WriteString(cell.value)
-	result.WriteString(`</`)
-	result.WriteString(tag)
-	result.WriteString(`>`)
-	result.WriteString("\n")
+	switch {
+	case len(cell.link) > 0:
+		writer.WriteString(`<a href="#` + escapeAttr(cell.link) + `">`)
+	case len(cell.href) > 0:
+		writer.WriteString(`<a href="` + escapeAttr(cell.href) + `">`)
+	}
+
+	switch {
+	case cell.nested != nil:
+		cell.nested.writeHTML(writer)
+	case cell.html:
+		writer.WriteString(cell.value)
+	default:
+		writer.WriteString(escapeText(cell.value))
+	}
+
+	if len(cell.link) > 0 || len(cell.href) > 0 {
+		writer.WriteString(`</a>`)
+	}
 
-	return result.String()
+	writer.WriteString(`</` + tag + ">\n")
 }
 
 type tableT struct {
+	id      string
 	caption string
 	header  headerT
 	body    bodyT
 	columns int
+	visited map[visitKey]visitT
+	dumper  *Dumper
+	doc     *htmlDocument
+	// nested tables are shown inside a cell of another table, depth counts
+	// the nesting levels above them.
+	nested bool
+	depth  int
+	// fields are the columns of the struct elements of a slice or map table.
+	fields []*columnT
+	// keys are the columns of the map elements of a slice table.
+	keys []reflect.Value
+	// path holds the pointers, slices and maps being rendered by this table
+	// and the tables it is nested in, to stop cycles through nested tables.
+	path map[visitKey]visitT
+	// truncated is set once any part of the table was left out by the limits.
+	truncated bool
+	// full is set once a struct table reached the row limit.
+	full bool
+	// paged tables are a page of a paginated collection and show all its rows.
+	paged bool
+	// stream is the writer the rows of a begun table go to as they are added,
+	// see begin. Markdown writes the tables nested in them after the table,
+	// they are kept in following until then.
+	stream    htmlWriter
+	following []*tableT
+	// announced is set when the caption written by begin said the table is truncated.
+	announced bool
+	// rows counts the body rows added, streamed or kept.
+	rows int
+}
+
+// visitKey identifies a struct already rendered in the table. The type is part
+// of the key because a struct and its first field share the same address.
+type visitKey struct {
+	address   uintptr
+	valueType reflect.Type
+}
+
+// visitT points to the place where a struct was rendered first.
+type visitT struct {
+	anchor string
+	label  string
 }
 
 type (
@@ -96,6 +202,24 @@ type (
 	bodyT   []rowT
 )
 
+func (row *rowT) openTag() string {
+	tag := `<tr`
+
+	if len(row.id) > 0 {
+		tag += ` id="` + escapeAttr(row.id) + `"`
+	}
+
+	if row.level > 0 {
+		tag += ` data-level="` + strconv.Itoa(row.level) + `"`
+	}
+
+	if row.branch {
+		tag += ` data-branch`
+	}
+
+	return tag + `>`
+}
+
 func (row *rowT) addCell(cell cellT) {
 	row.cells = append(row.cells, cell)
 }
@@ -111,38 +235,69 @@ func (row *rowT) addCellStr(value string, styles ...styleT) *rowT {
 	return row
 }
 
-func (header *headerT) toHTML() string {
-	var html string
+// writeRows writes the rows, with cells of the tag. With columns set, header
+// cells tell which column they sort by and body cells which column they start
+// at, for the sorting script.
+func writeRows(writer htmlWriter, rows []rowT, tag string, columns bool) {
+	for _, row := range rows {
+		writer.WriteString("      " + row.openTag() + "\n")
+
+		column := 0
 
-	for _, row := range *header {
-		html += "      <tr>\n"
 		for _, cell := range row.cells {
-			html += `        ` + cell.toHTML(`th`)
+			position := -1
+
+			switch {
+			case !columns:
+			case tag == `th` && cell.sortable:
+				position = cell.column
+			case tag == `td`:
+				position = column
+			}
+
+			writer.WriteString(`        `)
+			cell.writeHTML(writer, tag, position)
+
+			column += max(cell.colspan, 1)
 		}
 
-		html += "      </tr>\n"
+		writer.WriteString("      </tr>\n")
 	}
-
-	return strings.TrimSuffix(html, "\n")
 }
 
-func (body *bodyT) toHTML() string {
-	var html string
-
-	for _, row := range *body {
-		html += "      <tr>\n"
-		for _, cell := range row.cells {
-			html += `        ` + cell.toHTML(`td`)
+func (table *tableT) addBodyRow(row rowT) {
+	for idx := range row.cells {
+		if table.dumper.limits.truncateValue(&row.cells[idx]) {
+			table.truncated = true
 		}
-
-		html += "      </tr>\n"
 	}
 
-	return strings.TrimSuffix(html, "\n")
+	table.addRow(row)
 }
 
-func (table *tableT) addBodyRow(row rowT) {
-	table.body = append(table.body, row)
+// addTruncatedRow adds the marker row spanning all columns and flags the table
+// as truncated.
+func (table *tableT) addTruncatedRow(value string) {
+	var row rowT
+
+	row.addCell(truncatedCell(value, table.columns))
+
+	table.truncated = true
+	table.addRow(row)
+}
+
+// addRow writes the row of a begun table, other tables keep it until they are written.
+func (table *tableT) addRow(row rowT) {
+	table.rows++
+
+	switch {
+	case table.stream == nil:
+		table.body = append(table.body, row)
+	case table.doc.format == formatMarkdown:
+		table.following = append(table.following, table.writeMarkdownRow(table.stream, &row)...)
+	default:
+		writeRows(table.stream, []rowT{row}, `td`, table.sortable())
+	}
 }
 
 func (table *tableT) addHeaderRow(row rowT) {
@@ -154,108 +309,143 @@ func (table *tableT) Caption(caption string) *tableT {
 	return table
 }
 
-func (table *tableT) toHTML(doc *htmlDocument) {
-	doc.add(`  <table class="styled-table">`)
-
-	if len(table.caption) > 0 {
-		doc.add(`    <caption>` + table.caption + `</caption>`)
+// begin starts writing the table of a slice or map of the length to its
+// document, once the header is added. The length tells whether rows are left
+// out by the row limit, so the caption goes out first and the rows follow as
+// they are added, instead of being kept until the table is complete. Values
+// cut later, and nested tables reaching a limit, are reported by a note after
+// the rows, see lateTruncation. Text tables are still written whole by end, as
+// their column widths follow the widest value.
+func (table *tableT) begin(length int) *tableT {
+	if !table.paged && table.dumper.limits.rowsTruncated(length) {
+		table.truncated = true
 	}
 
-	doc.add(`    <thead>`).
-		add(table.header.toHTML()).
-		add(`    </thead>`)
-	doc.add(`    <tbody>`).
-		add(table.body.toHTML()).
-		add(`    </tbody>`)
-	doc.add(`  </table>`)
-}
+	table.announced = table.truncated
 
-func (table *tableT) headerRow(valueType reflect.Type, captions, types *rowT) {
-	if valueType.Kind() == reflect.Pointer {
-		valueType = valueType.Elem()
+	switch table.doc.format {
+	case formatText:
+		return table
+	case formatMarkdown:
+		table.writeMarkdownHead(table.doc.writer, markdownHeading)
+	default:
+		table.writeHTMLHead(table.doc.writer)
 	}
 
-	if valueType.Kind() == reflect.Struct {
-		structType := valueType
-		for idx := 0; idx < structType.NumField(); idx++ {
-			field := structType.Field(idx)
+	table.stream = table.doc.writer
 
-			if isStructOrPointerToStruct(field.Type) && !isSkippedType(field.Type) {
-				captions.addCell(cellT{
-					value:   structFieldCaption(field),
-					colspan: structNumbeOfFields(field.Type),
-				})
-
-				embeddedStructHeader(field.Type, types)
-
-				continue
-			}
+	return table
+}
 
-			captions.addCellStr(field.Name)
+// lateTruncation reports whether a begun table was truncated after its caption
+// was written, which then did not say so.
+func (table *tableT) lateTruncation() bool {
+	return table.stream != nil && table.truncated && !table.announced
+}
 
-			fieldType := field.Type
-			if field.Type.Kind() == reflect.Pointer {
-				fieldType = field.Type.Elem()
-				types.addCellStr(`*` + fieldType.Name())
-			} else {
-				types.addCellStr(fieldType.Name())
-			}
-		}
-	} else {
-		captions.addCellStr(`value`)
-		types.addCellStr(valueType.Name())
+// truncationNote is the text of the note ending a table truncated after its caption.
+const truncationNote = `… output truncated`
+
+// end completes the begun table.
+func (table *tableT) end() {
+	switch {
+	case table.stream == nil:
+		table.write(table.doc)
+	case table.doc.format == formatMarkdown:
+		table.writeMarkdownTail(table.stream, markdownHeading, table.following)
+	default:
+		table.writeHTMLTail(table.stream)
 	}
+}
 
-	table.addHeaderRow(*captions)
-	table.addHeaderRow(*types)
+// write writes the complete table to the document in its format, for tables
+// keeping their rows until then.
+func (table *tableT) write(doc *htmlDocument) {
+	switch doc.format {
+	case formatMarkdown:
+		table.writeMarkdown(doc.writer, markdownHeading)
+	case formatText:
+		table.writeText(doc.writer)
+	default:
+		table.writeHTML(doc.writer)
+	}
+}
 
-	table.columns = len(types.cells)
+// writeHTML writes the table markup. Nested tables show their caption as
+// the summary of a details element, so they can be collapsed.
+func (table *tableT) writeHTML(html htmlWriter) {
+	table.writeHTMLHead(html)
+	writeRows(html, table.body, `td`, table.sortable())
+	table.writeHTMLTail(html)
 }
 
-func embeddedStructHeader(structType reflect.Type, types *rowT) {
-	if structType.Kind() == reflect.Pointer {
-		structType = structType.Elem()
+// writeHTMLHead writes the markup of the table up to its first body row.
+func (table *tableT) writeHTMLHead(html htmlWriter) {
+	class := `styled-table`
+	if table.nested {
+		class += ` nested`
 	}
 
-	for idx := 0; idx < structType.NumField(); idx++ {
-		structField := structType.Field(idx)
-		fieldType := structField.Type
-		fieldTypeName := fieldType.Name()
-
-		if fieldType.Kind() == reflect.Pointer {
-			fieldType = fieldType.Elem()
-			fieldTypeName = `*` + fieldType.Name()
-		}
+	caption := table.caption
+	if table.truncated {
+		caption += ` — output truncated`
+	}
 
-		if structField.Name == fieldTypeName {
-			types.addCellStr(fieldTypeName)
+	if table.nested {
+		html.WriteString(`<details`)
 
-			continue
+		if !table.dumper.collapseNested {
+			html.WriteString(` open`)
 		}
 
-		types.addCellStr(fmt.Sprintf(`%s(%s)`, structField.Name, fieldTypeName))
+		html.WriteString(`><summary>` + escapeText(caption) + "</summary>\n")
 	}
-}
 
-func structNumbeOfFields(structType reflect.Type) int {
-	if structType.Kind() == reflect.Ptr {
-		structType = structType.Elem()
+	if len(table.id) > 0 {
+		html.WriteString(`  <table class="` + class + `" id="` + escapeAttr(table.id) + "\">\n")
+	} else {
+		html.WriteString(`  <table class="` + class + "\">\n")
 	}
 
-	return structType.NumField()
+	if len(caption) > 0 && !table.nested {
+		html.WriteString(`    <caption>` + escapeText(caption) + "</caption>\n")
+	}
+
+	html.WriteString("    <thead>\n")
+	writeRows(html, table.header, `th`, table.sortable())
+	html.WriteString("    </thead>\n")
+
+	html.WriteString("    <tbody>\n")
 }
 
-func structFieldCaption(field reflect.StructField) string {
-	fieldTypeName := field.Type.Name()
+// writeHTMLTail writes the markup of the table after its last body row, with
+// a footer noting a truncation the caption did not report.
+func (table *tableT) writeHTMLTail(html htmlWriter) {
+	html.WriteString("    </tbody>\n")
+
+	if table.lateTruncation() {
+		var row rowT
+
+		row.addCell(truncatedCell(truncationNote, table.columns))
 
-	if field.Type.Kind() == reflect.Pointer {
-		field.Type = field.Type.Elem()
-		fieldTypeName = `*` + field.Type.Name()
+		html.WriteString("    <tfoot>\n")
+		writeRows(html, []rowT{row}, `td`, false)
+		html.WriteString("    </tfoot>\n")
 	}
+	html.WriteString("  </table>\n")
+
+	if table.nested {
+		html.WriteString("</details>\n")
+	}
+}
+
+func structFieldCaption(field reflect.StructField, tag fieldTagT) string {
+	fieldName := tag.label(field)
+	fieldTypeName := getFieldTypeName(field.Type)
 
-	if field.Name == fieldTypeName {
-		return field.Name
+	if fieldName == fieldTypeName {
+		return fieldName
 	}
 
-	return fmt.Sprintf(`%s(%s)`, field.Name, fieldTypeName)
+	return fmt.Sprintf(`%s(%s)`, fieldName, fieldTypeName)
 }
@@ -0,0 +1,95 @@
+package htmldump
+
+import (
+	"reflect"
+	"strings"
+)
+
+// TagName is the struct tag read to control how a field is rendered:
+//
+//	Password string    `htmldump:"-"`                  // hidden
+//	UserID   int64     `htmldump:"ID"`                 // renamed
+//	Flags    uint32    `htmldump:",format=%08b"`       // fmt verb for the value
+//	Created  time.Time `htmldump:",layout=2006-01-02"` // time layout
+//	Address  Address   `htmldump:",inline"`           // fields shown as the parent's own
+//	Config   Config    `htmldump:",collapse"`         // shown as one value, not expanded
+//
+// The layout option takes the rest of the tag, so it has to be the last one
+// and may contain commas.
+const TagName = `htmldump`
+
+type fieldTagT struct {
+	skip     bool
+	name     string
+	format   string
+	layout   string
+	inline   bool
+	collapse bool
+}
+
+func parseFieldTag(field reflect.StructField) fieldTagT {
+	var tag fieldTagT
+
+	value, ok := field.Tag.Lookup(TagName)
+	if !ok {
+		return tag
+	}
+
+	if value == `-` {
+		tag.skip = true
+
+		return tag
+	}
+
+	name, options, _ := strings.Cut(value, `,`)
+	tag.name = name
+
+	for len(options) > 0 {
+		var option string
+
+		if strings.HasPrefix(options, `layout=`) {
+			tag.layout = strings.TrimPrefix(options, `layout=`)
+
+			break
+		}
+
+		option, options, _ = strings.Cut(options, `,`)
+
+		switch {
+		case option == `inline`:
+			tag.inline = true
+		case option == `collapse`:
+			tag.collapse = true
+		case strings.HasPrefix(option, `format=`):
+			tag.format = strings.TrimPrefix(option, `format=`)
+		}
+	}
+
+	return tag
+}
+
+// label returns the name the field is shown under.
+func (tag fieldTagT) label(field reflect.StructField) string {
+	if len(tag.name) > 0 {
+		return tag.name
+	}
+
+	return field.Name
+}
+
+// expands reports whether a field of the type is rendered as a nested struct.
+func (dumper *Dumper) expands(fieldType reflect.Type, tag fieldTagT) bool {
+	return !tag.collapse && len(tag.format) == 0 && dumper.isExpandable(fieldType)
+}
+
+// formats returns the fmt verb for the field value, collapsed structs show their field names.
+func (tag fieldTagT) formats() []string {
+	switch {
+	case len(tag.format) > 0:
+		return []string{tag.format}
+	case tag.collapse:
+		return []string{`%+v`}
+	default:
+		return nil
+	}
+}
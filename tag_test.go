@@ -0,0 +1,86 @@
+package htmldump_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/oslyak/htmldump"
+
+	"github.com/stretchr/testify/require"
+)
+
+type address struct {
+	City   string
+	Street string `htmldump:"-"`
+}
+
+type account struct {
+	UserID   int64     `htmldump:"ID"`
+	Password string    `htmldump:"-"`
+	Flags    uint8     `htmldump:",format=%08b"`
+	Created  time.Time `htmldump:"Created at,layout=Jan 2, 2006"`
+	Home     address   `htmldump:",inline"`
+	Work     *address  `htmldump:",collapse"`
+}
+
+func TestFieldTags(t *testing.T) {
+	t.Parallel()
+
+	input := account{
+		UserID:   7,
+		Password: `secret`,
+		Flags:    5,
+		Created:  time.Date(2024, 5, 17, 10, 0, 0, 0, time.UTC),
+		Home:     address{City: `Kyiv`, Street: `Khreshchatyk`},
+		Work:     &address{City: `Lviv`},
+	}
+
+	buffer := bytes.NewBuffer([]byte{})
+	err := htmldump.ToHTML(buffer, input)
+	require.NoError(t, err)
+
+	table := extractHTMLTable(t, buffer.String())
+	table = removeStyle(t, table)
+
+	require.NotContains(t, table, `secret`)
+	require.NotContains(t, table, `Khreshchatyk`)
+	require.Contains(t, table, `<tr><td>ID</td><td>int64</td><td>7</td></tr>`)
+	require.Contains(t, table, `<tr><td>Flags</td><td>uint8</td><td>00000101</td></tr>`)
+	require.Contains(t, table, `<tr><td>Created at</td><td>Time</td><td>May 17, 2024</td></tr>`)
+	require.Contains(t, table, `<tr><td>City</td><td>string</td><td>Kyiv</td></tr>`)
+	require.Contains(t, table, `<tr><td>Work</td><td>*address</td><td>{City:Lviv}</td></tr>`)
+
+	buffer = bytes.NewBuffer([]byte{})
+	err = htmldump.ToHTML(buffer, []account{input, {}})
+	require.NoError(t, err)
+
+	table = extractHTMLTable(t, buffer.String())
+	table = removeStyle(t, table)
+
+	require.Contains(t, table, `<tr><th>index</th><th>ID</th><th>Flags</th><th>Created at</th><th>City</th><th>Work</th></tr>`)
+	require.Contains(t, table, `<tr><td>0</td><td>7</td><td>00000101</td><td>May 17, 2024</td><td>Kyiv</td><td>{City:Lviv}</td></tr>`)
+	require.Contains(t, table, `<td>Kyiv</td><td>{City:Lviv}</td></tr><tr><td>1</td>`)
+	require.Contains(t, table, `<td>NULL</td></tr></tbody>`)
+}
+
+type chainLink struct {
+	Name string
+	Next *chainLink `htmldump:",collapse"`
+}
+
+func TestCollapsedCycle(t *testing.T) {
+	t.Parallel()
+
+	link := &chainLink{Name: `a`}
+	link.Next = link
+
+	buffer := bytes.NewBuffer([]byte{})
+	err := htmldump.ToHTML(buffer, link)
+	require.NoError(t, err)
+
+	table := extractHTMLTable(t, buffer.String())
+	table = removeStyle(t, table)
+
+	require.Contains(t, table, `<tr><td>Next</td><td>*chainLink</td><td>{Name:a Next:↺}</td></tr>`)
+}
@@ -0,0 +1,389 @@
+package htmldump
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// TextOverflow is how the text renderer fits values into the terminal width.
+type TextOverflow int
+
+const (
+	// TextWrap breaks long values into several lines.
+	TextWrap TextOverflow = iota
+	// TextTruncate cuts long values, marking the cut with an ellipsis.
+	TextTruncate
+)
+
+// minTextColumn is the width columns are not narrowed below to fit the terminal.
+const minTextColumn = 3
+
+// ANSI escape sequences of the text renderer, mirroring the HTML styling.
+const (
+	ansiReset     = "\x1b[0m"
+	ansiCaption   = "\x1b[1m"
+	ansiHeader    = "\x1b[1;97;42m"
+	ansiKey       = "\x1b[1;32m"
+	ansiNested    = "\x1b[36m"
+	ansiMuted     = "\x1b[2;3m"
+	ansiSeparator = "\x1b[90m"
+)
+
+// textCellT is a cell laid out for the text renderer.
+type textCellT struct {
+	lines []string
+	span  int
+	style string
+}
+
+// ToText dumps the inputs to the writer as box-drawn text tables, for
+// terminals without a browser.
+// It is a shortcut for DumpText of a Dumper with the default options.
+func ToText(writer io.Writer, inputs ...interface{}) error {
+	if len(inputs) == 0 {
+		return errors.New(`[ToText] requires at least one inputs argument`)
+	}
+
+	return defaultDumper().DumpText(writer, inputs...)
+}
+
+// DumpText writes the inputs to the writer as box-drawn text tables. Column
+// widths follow the content, and with WithTextWidth the tables fit the
+// terminal by wrapping or truncating values. Nested tables follow the table
+// they are nested in.
+func (dumper *Dumper) DumpText(writer io.Writer, inputs ...interface{}) error {
+	if len(inputs) == 0 {
+		return errors.New(`[DumpText] requires at least one inputs argument`)
+	}
+
+	return dumper.dumpDocument(newTextDocument(writer, dumper, formatText), inputs)
+}
+
+// WithTextWidth sets the terminal width text tables are fitted to,
+// zero means no limit.
+func WithTextWidth(columns int) Option {
+	return func(dumper *Dumper) {
+		dumper.textWidth = columns
+	}
+}
+
+// WithTextOverflow sets how text tables fit long values into the terminal width.
+func WithTextOverflow(overflow TextOverflow) Option {
+	return func(dumper *Dumper) {
+		dumper.textOverflow = overflow
+	}
+}
+
+// WithANSIColors colours text tables with ANSI escape sequences, like the
+// HTML document: the header, key cells, nested struct fields and the
+// placeholders of truncated and absent values.
+func WithANSIColors() Option {
+	return func(dumper *Dumper) {
+		dumper.ansiColors = true
+	}
+}
+
+// writeText writes the table with its caption above, then the tables nested
+// in its cells.
+func (table *tableT) writeText(writer htmlWriter) {
+	caption := table.caption
+	if table.truncated {
+		caption += ` — output truncated`
+	}
+
+	if table.nested {
+		caption = table.id + `: ` + caption
+	}
+
+	if len(caption) > 0 {
+		writer.WriteString(table.dumper.colored(escapeControls(caption), ansiCaption) + "\n")
+	}
+
+	names := table.columnNames()
+
+	header := make([]textCellT, len(names))
+	for idx, name := range names {
+		header[idx] = textCellT{lines: []string{escapeControls(name)}, span: 1, style: ansiHeader}
+	}
+
+	var nested []*tableT
+
+	rows := make([][]textCellT, 0, len(table.body))
+
+	for _, row := range table.body {
+		cells := make([]textCellT, 0, len(row.cells))
+
+		for _, cell := range row.cells {
+			if cell.nested != nil {
+				nested = append(nested, cell.nested)
+			}
+
+			cells = append(cells, textCell(&cell))
+		}
+
+		rows = append(rows, cells)
+	}
+
+	widths := table.dumper.textWidths(header, rows, len(names))
+
+	writer.WriteString(table.dumper.textBorder(widths, `┌`, `┬`, `┐`))
+
+	// Tables without a header, such as the ones of scalars, start with their body.
+	if len(table.header) > 0 {
+		writer.WriteString(table.dumper.textRow(header, widths))
+		writer.WriteString(table.dumper.textBorder(widths, `├`, `┼`, `┤`))
+	}
+
+	for _, row := range rows {
+		writer.WriteString(table.dumper.textRow(row, widths))
+	}
+
+	writer.WriteString(table.dumper.textBorder(widths, `└`, `┴`, `┘`))
+	writer.WriteString("\n")
+
+	for _, table := range nested {
+		table.writeText(writer)
+	}
+}
+
+// textCell returns the plain text lines of the cell and its style. Struct
+// fields keep their indent, nested tables are shown as a pointer to where
+// they follow. Control characters other than line breaks are escaped.
+func textCell(cell *cellT) textCellT {
+	value := cell.value
+
+	switch {
+	case cell.nested != nil:
+		value = `↓ ` + cell.nested.id
+	case cell.html && len(cell.text) > 0:
+		value = cell.text
+	}
+
+	if len(cell.dynamicType) > 0 {
+		value = `(` + cell.dynamicType + `) ` + value
+	}
+
+	indent := strings.Repeat(` `, cell.paddingLeft/6)
+	value = strings.ReplaceAll(strings.ReplaceAll(value, "\r\n", "\n"), "\t", `    `)
+	value = escapeControls(value)
+
+	lines := strings.Split(value, "\n")
+	for idx := range lines {
+		lines[idx] = indent + lines[idx]
+	}
+
+	style := ``
+
+	switch {
+	case cell.key:
+		style = ansiKey
+	case cell.truncated, cell.absent:
+		style = ansiMuted
+	case len(cell.background) > 0:
+		style = ansiNested
+	}
+
+	return textCellT{lines: lines, span: max(cell.colspan, 1), style: style}
+}
+
+// escapeControls replaces the C0 and C1 control characters of the text, except
+// line feeds, with visible escapes such as \x1b, so values cannot move the
+// cursor, change colours or ring the bell of the terminal.
+func escapeControls(text string) string {
+	var escaped strings.Builder
+
+	for _, char := range text {
+		if char != '\n' && (char < 0x20 || char >= 0x7f && char <= 0x9f) {
+			fmt.Fprintf(&escaped, `\x%02x`, char)
+
+			continue
+		}
+
+		escaped.WriteRune(char)
+	}
+
+	return escaped.String()
+}
+
+// textWidths returns the width of each column: the widest of its values,
+// narrowed to fit the terminal width when it is set.
+func (dumper *Dumper) textWidths(header []textCellT, rows [][]textCellT, columns int) []int {
+	widths := make([]int, columns)
+
+	measure := func(row []textCellT, spanning bool) {
+		column := 0
+
+		for _, cell := range row {
+			if column >= columns {
+				break
+			}
+
+			span := min(cell.span, columns-column)
+			if (span > 1) == spanning {
+				width := 0
+				for _, line := range cell.lines {
+					width = max(width, utf8.RuneCountInString(line))
+				}
+
+				// Spanning cells widen the last of their columns when needed.
+				available := 3 * (span - 1)
+				for idx := column; idx < column+span; idx++ {
+					available += widths[idx]
+				}
+
+				if width > available {
+					widths[column+span-1] += width - available
+				}
+			}
+
+			column += span
+		}
+	}
+
+	for _, spanning := range []bool{false, true} {
+		measure(header, spanning)
+
+		for _, row := range rows {
+			measure(row, spanning)
+		}
+	}
+
+	if dumper.textWidth <= 0 {
+		return widths
+	}
+
+	total := 3*columns + 1
+	for _, width := range widths {
+		total += width
+	}
+
+	for total > dumper.textWidth {
+		widest := 0
+		for idx := range widths {
+			if widths[idx] > widths[widest] {
+				widest = idx
+			}
+		}
+
+		if widths[widest] <= minTextColumn {
+			break
+		}
+
+		widths[widest]--
+		total--
+	}
+
+	return widths
+}
+
+// textBorder returns a horizontal border line of the table.
+func (dumper *Dumper) textBorder(widths []int, left, middle, right string) string {
+	parts := make([]string, len(widths))
+	for idx, width := range widths {
+		parts[idx] = strings.Repeat(`─`, width+2)
+	}
+
+	return dumper.colored(left+strings.Join(parts, middle)+right, ansiSeparator) + "\n"
+}
+
+// textRow returns the lines of the row, values too wide for their column
+// wrapped or truncated.
+func (dumper *Dumper) textRow(row []textCellT, widths []int) string {
+	type segmentT struct {
+		lines []string
+		width int
+		style string
+	}
+
+	var segments []segmentT
+
+	height := 1
+	column := 0
+
+	for _, cell := range row {
+		if column >= len(widths) {
+			break
+		}
+
+		span := min(cell.span, len(widths)-column)
+
+		width := 3 * (span - 1)
+		for idx := column; idx < column+span; idx++ {
+			width += widths[idx]
+		}
+
+		lines := dumper.fitText(cell.lines, width)
+		height = max(height, len(lines))
+
+		segments = append(segments, segmentT{lines: lines, width: width, style: cell.style})
+		column += span
+	}
+
+	// Rows with fewer cells than columns are padded with empty ones.
+	for ; column < len(widths); column++ {
+		segments = append(segments, segmentT{width: widths[column]})
+	}
+
+	separator := dumper.colored(`│`, ansiSeparator)
+
+	var text strings.Builder
+
+	for line := range height {
+		text.WriteString(separator)
+
+		for _, segment := range segments {
+			value := ``
+			if line < len(segment.lines) {
+				value = segment.lines[line]
+			}
+
+			padding := strings.Repeat(` `, max(segment.width-utf8.RuneCountInString(value), 0))
+
+			text.WriteString(dumper.colored(` `+value+padding+` `, segment.style))
+			text.WriteString(separator)
+		}
+
+		text.WriteString("\n")
+	}
+
+	return text.String()
+}
+
+// fitText wraps or truncates the lines to the width.
+func (dumper *Dumper) fitText(lines []string, width int) []string {
+	var fitted []string
+
+	width = max(width, 1)
+
+	for _, line := range lines {
+		runes := []rune(line)
+
+		switch {
+		case len(runes) <= width:
+			fitted = append(fitted, line)
+		case dumper.textOverflow == TextTruncate:
+			fitted = append(fitted, string(runes[:width-1])+`…`)
+		default:
+			for len(runes) > width {
+				fitted = append(fitted, string(runes[:width]))
+				runes = runes[width:]
+			}
+
+			fitted = append(fitted, string(runes))
+		}
+	}
+
+	return fitted
+}
+
+// colored wraps the text in the ANSI style when colours are on.
+func (dumper *Dumper) colored(text, style string) string {
+	if !dumper.ansiColors || len(style) == 0 {
+		return text
+	}
+
+	return style + text + ansiReset
+}
@@ -0,0 +1,79 @@
+package htmldump_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/oslyak/htmldump"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToText(t *testing.T) {
+	t.Parallel()
+
+	orders := []customerOrder{
+		{ID: 1, Customer: customer{Name: "Ann\nLtd", Address: &postalAddress{City: `Oslo`, Street: `Main street 1`}}},
+		{ID: 2, Customer: customer{Name: `Bob`}},
+	}
+
+	buffer := bytes.NewBuffer([]byte{})
+	err := htmldump.ToText(buffer, orders, newFilterAccounts())
+	require.NoError(t, err)
+
+	text := buffer.String()
+
+	require.Contains(t, text, "[]htmldump_test.customerOrder (length: 2)\n"+
+		"┌────────────┬─────────┬───────────────────────┬───────────────────────────────┬─────────────────────────────────┐\n"+
+		"│ index(int) │ ID(int) │ Customer.Name(string) │ Customer.Address.City(string) │ Customer.Address.Street(string) │\n"+
+		"├────────────┼─────────┼───────────────────────┼───────────────────────────────┼─────────────────────────────────┤\n"+
+		"│ 0          │ 1       │ Ann                   │ Oslo                          │ Main street 1                   │\n"+
+		"│            │         │ Ltd                   │                               │                                 │\n"+
+		"│ 1          │ 2       │ Bob                   │ NULL                                                            │\n"+
+		"└────────────┴─────────┴───────────────────────┴───────────────────────────────┴─────────────────────────────────┘\n")
+	require.Contains(t, text, "│ filter           │ filter  │")
+	require.Contains(t, text, "│   IncludeRemoved │ bool    │")
+	require.Contains(t, text, "│     Column       │ string  │ pointer")
+	require.NotContains(t, text, "\x1b[")
+
+	for _, overflow := range []htmldump.TextOverflow{htmldump.TextWrap, htmldump.TextTruncate} {
+		buffer = bytes.NewBuffer([]byte{})
+		err = htmldump.NewDumper(htmldump.WithTextWidth(60), htmldump.WithTextOverflow(overflow)).DumpText(buffer, orders)
+		require.NoError(t, err)
+
+		for _, line := range strings.Split(buffer.String(), "\n") {
+			if strings.HasPrefix(line, `│`) {
+				require.Equal(t, 60, utf8.RuneCountInString(line), line)
+			}
+		}
+
+		if overflow == htmldump.TextTruncate {
+			require.Contains(t, buffer.String(), "│ Main stre… │\n")
+		} else {
+			require.Contains(t, buffer.String(), "│ Main stree │\n")
+			require.Contains(t, buffer.String(), "│ t 1        │\n")
+		}
+	}
+
+	buffer = bytes.NewBuffer([]byte{})
+	err = htmldump.NewDumper(htmldump.WithANSIColors()).DumpText(buffer, orders)
+	require.NoError(t, err)
+
+	require.Contains(t, buffer.String(), "\x1b[1;97;42m index(int) \x1b[0m")
+	require.Contains(t, buffer.String(), "\x1b[1;32m 0          \x1b[0m")
+}
+
+func TestToTextHeaderless(t *testing.T) {
+	t.Parallel()
+
+	buffer := bytes.NewBuffer([]byte{})
+	err := htmldump.ToText(buffer, 42, "plain\x1b[31mred\x07\u0085")
+	require.NoError(t, err)
+
+	text := buffer.String()
+
+	require.Contains(t, text, "int\n┌───────┬────┐\n│ Value │ 42 │\n└───────┴────┘\n")
+	require.Contains(t, text, "│ Value │ plain\\x1b[31mred\\x07\\x85 │\n")
+	require.NotContains(t, text, "\x1b")
+}
@@ -0,0 +1,280 @@
+package htmldump
+
+import (
+	"strconv"
+	"strings"
+)
+
+// gradientLevels is the number of nesting levels with their own background,
+// deeper levels share the last one.
+const gradientLevels = 4
+
+// themeValueStripper removes from theme values the characters that would end
+// their declaration, their rule or the style element.
+var themeValueStripper = strings.NewReplacer(`<`, ``, `>`, ``, `{`, ``, `}`, ``, `;`, ``)
+
+// Theme holds the colours and fonts of the generated document. Colours are
+// CSS values, such as `#009879` or `rgb(220, 220, 220)`. The characters < > { } ;
+// are stripped from the values.
+type Theme struct {
+	// PageBackground and PageText colour the page around the tables.
+	PageBackground string
+	PageText       string
+	Link           string
+
+	FontFamily string
+	FontSize   string
+	// MonoFont is used for JSON, SQL and hex dumps.
+	MonoFont string
+
+	Border            string
+	CaptionBackground string
+	CaptionText       string
+
+	HeaderBackground string
+	HeaderText       string
+	HeaderBorder     string
+
+	// EvenRow and OddRow are the zebra striping of body rows.
+	EvenRow  string
+	OddRow   string
+	Grid     string
+	RowHover string
+
+	// KeyBackground and KeyText style the index and map key cells.
+	KeyBackground string
+	KeyText       string
+
+	// Gradient holds the backgrounds of nested struct fields by nesting level,
+	// starting from the first nested level. The last one is used for deeper levels.
+	Gradient []string
+
+	// Muted colours the placeholders for absent, truncated and disabled values.
+	Muted           string
+	BadgeBackground string
+	BadgeText       string
+	Accent          string
+
+	SyntaxKey     string
+	SyntaxString  string
+	SyntaxNumber  string
+	SyntaxKeyword string
+}
+
+// LightTheme is the default theme.
+var LightTheme = Theme{
+	PageBackground:    `#ffffff`,
+	PageText:          `#000000`,
+	Link:              `#0645ad`,
+	FontFamily:        `sans-serif`,
+	FontSize:          `0.9em`,
+	MonoFont:          `monospace`,
+	Border:            `rgb(150, 150, 150)`,
+	CaptionBackground: `rgb(220, 220, 220)`,
+	CaptionText:       `#000000`,
+	HeaderBackground:  `#009879`,
+	HeaderText:        `#ffffff`,
+	HeaderBorder:      `#006e58`,
+	EvenRow:           `rgb(230, 230, 230)`,
+	OddRow:            `rgb(250, 250, 250)`,
+	Grid:              `#dddddd`,
+	RowHover:          `#006650`,
+	KeyBackground:     `transparent`,
+	KeyText:           `#006650`,
+	Gradient:          []string{`#AFFFFF`, `#6DEFFF`, `#47D3FF`, `#00B7EB`},
+	Muted:             `#999999`,
+	BadgeBackground:   `#e8e8e8`,
+	BadgeText:         `#555555`,
+	Accent:            `#009879`,
+	SyntaxKey:         `#a31515`,
+	SyntaxString:      `#0b7a0b`,
+	SyntaxNumber:      `#1750eb`,
+	SyntaxKeyword:     `#871094`,
+}
+
+// DarkTheme is used when the browser prefers a dark colour scheme.
+var DarkTheme = Theme{
+	PageBackground:    `#1e1f22`,
+	PageText:          `#dcdcdc`,
+	Link:              `#6cb6ff`,
+	FontFamily:        `sans-serif`,
+	FontSize:          `0.9em`,
+	MonoFont:          `monospace`,
+	Border:            `#4a4d52`,
+	CaptionBackground: `#2b2d31`,
+	CaptionText:       `#e6e6e6`,
+	HeaderBackground:  `#0b5d4f`,
+	HeaderText:        `#e6fff9`,
+	HeaderBorder:      `#084539`,
+	EvenRow:           `#26282c`,
+	OddRow:            `#2e3035`,
+	Grid:              `#3a3d42`,
+	RowHover:          `#7fe0c8`,
+	KeyBackground:     `transparent`,
+	KeyText:           `#5fd4b4`,
+	Gradient:          []string{`#1d3b46`, `#1f4656`, `#225166`, `#255c76`},
+	Muted:             `#8a8d92`,
+	BadgeBackground:   `#3a3d42`,
+	BadgeText:         `#c0c0c0`,
+	Accent:            `#5fd4b4`,
+	SyntaxKey:         `#f28b82`,
+	SyntaxString:      `#8bd17c`,
+	SyntaxNumber:      `#79b8ff`,
+	SyntaxKeyword:     `#d7a6ff`,
+}
+
+// HighContrastTheme is used when the browser asks for more contrast with a
+// light colour scheme.
+var HighContrastTheme = Theme{
+	PageBackground:    `#ffffff`,
+	PageText:          `#000000`,
+	Link:              `#0000ee`,
+	FontFamily:        `sans-serif`,
+	FontSize:          `1em`,
+	MonoFont:          `monospace`,
+	Border:            `#000000`,
+	CaptionBackground: `#ffffff`,
+	CaptionText:       `#000000`,
+	HeaderBackground:  `#000000`,
+	HeaderText:        `#ffffff`,
+	HeaderBorder:      `#ffffff`,
+	EvenRow:           `#ffffff`,
+	OddRow:            `#ffffff`,
+	Grid:              `#000000`,
+	RowHover:          `#0000ee`,
+	KeyBackground:     `#ffff00`,
+	KeyText:           `#000000`,
+	Gradient:          []string{`#e0e0e0`, `#c0c0c0`},
+	Muted:             `#444444`,
+	BadgeBackground:   `#000000`,
+	BadgeText:         `#ffffff`,
+	Accent:            `#0000ee`,
+	SyntaxKey:         `#8b0000`,
+	SyntaxString:      `#005000`,
+	SyntaxNumber:      `#00008b`,
+	SyntaxKeyword:     `#000000`,
+}
+
+// HighContrastDarkTheme is used when the browser asks for more contrast with a
+// dark colour scheme.
+var HighContrastDarkTheme = Theme{
+	PageBackground:    `#000000`,
+	PageText:          `#ffffff`,
+	Link:              `#ffff00`,
+	FontFamily:        `sans-serif`,
+	FontSize:          `1em`,
+	MonoFont:          `monospace`,
+	Border:            `#ffffff`,
+	CaptionBackground: `#000000`,
+	CaptionText:       `#ffffff`,
+	HeaderBackground:  `#ffffff`,
+	HeaderText:        `#000000`,
+	HeaderBorder:      `#000000`,
+	EvenRow:           `#000000`,
+	OddRow:            `#000000`,
+	Grid:              `#ffffff`,
+	RowHover:          `#ffff00`,
+	KeyBackground:     `#000080`,
+	KeyText:           `#ffffff`,
+	Gradient:          []string{`#1a1a1a`, `#333333`},
+	Muted:             `#c0c0c0`,
+	BadgeBackground:   `#ffffff`,
+	BadgeText:         `#000000`,
+	Accent:            `#ffff00`,
+	SyntaxKey:         `#ff8080`,
+	SyntaxString:      `#80ff80`,
+	SyntaxNumber:      `#80c0ff`,
+	SyntaxKeyword:     `#ffffff`,
+}
+
+// WithTheme sets the theme of the document, used whatever colour scheme the
+// browser prefers.
+func WithTheme(theme Theme) Option {
+	return func(dumper *Dumper) {
+		dumper.theme = &theme
+	}
+}
+
+// WithColorSchemes sets the themes picked by the colour scheme the browser
+// prefers. By default these are LightTheme and DarkTheme, and HighContrastTheme
+// or HighContrastDarkTheme when the browser asks for more contrast. The high
+// contrast themes are not used with the themes set here.
+func WithColorSchemes(light, dark Theme) Option {
+	return func(dumper *Dumper) {
+		dumper.theme = nil
+		dumper.lightTheme = &light
+		dumper.darkTheme = &dark
+	}
+}
+
+// themeStyle returns the CSS variables of the document themes.
+func (dumper *Dumper) themeStyle() string {
+	if dumper.theme != nil {
+		return `    :root {` + dumper.theme.variables(`        `) + "\n    }\n"
+	}
+
+	if dumper.lightTheme != nil {
+		return colorSchemesStyle(dumper.lightTheme, dumper.darkTheme)
+	}
+
+	return colorSchemesStyle(&LightTheme, &DarkTheme) +
+		"    @media (prefers-contrast: more) and (prefers-color-scheme: light) {\n" +
+		`      :root {` + HighContrastTheme.variables(`          `) + "\n      }\n    }\n" +
+		"    @media (prefers-contrast: more) and (prefers-color-scheme: dark) {\n" +
+		`      :root {` + HighContrastDarkTheme.variables(`          `) + "\n      }\n    }\n"
+}
+
+// colorSchemesStyle returns the CSS variables of the light theme, and of the
+// dark one when the browser prefers a dark colour scheme.
+func colorSchemesStyle(light, dark *Theme) string {
+	return `    :root {` + light.variables(`        `) + "\n    }\n" +
+		"    @media (prefers-color-scheme: dark) {\n" +
+		`      :root {` + dark.variables(`          `) + "\n      }\n    }\n"
+}
+
+// variables returns the theme as CSS custom property declarations, a line each.
+func (theme *Theme) variables(indent string) string {
+	var css strings.Builder
+
+	declare := func(name, value string) {
+		css.WriteString("\n" + indent + `--` + name + `: ` + themeValueStripper.Replace(value) + `;`)
+	}
+
+	declare(`page-background`, theme.PageBackground)
+	declare(`page-text`, theme.PageText)
+	declare(`link`, theme.Link)
+	declare(`font-family`, theme.FontFamily)
+	declare(`font-size`, theme.FontSize)
+	declare(`mono-font`, theme.MonoFont)
+	declare(`border`, theme.Border)
+	declare(`caption-background`, theme.CaptionBackground)
+	declare(`caption-text`, theme.CaptionText)
+	declare(`header-background`, theme.HeaderBackground)
+	declare(`header-text`, theme.HeaderText)
+	declare(`header-border`, theme.HeaderBorder)
+	declare(`even-row`, theme.EvenRow)
+	declare(`odd-row`, theme.OddRow)
+	declare(`grid`, theme.Grid)
+	declare(`row-hover`, theme.RowHover)
+	declare(`key-background`, theme.KeyBackground)
+	declare(`key-text`, theme.KeyText)
+	declare(`muted`, theme.Muted)
+	declare(`badge-background`, theme.BadgeBackground)
+	declare(`badge-text`, theme.BadgeText)
+	declare(`accent`, theme.Accent)
+	declare(`syntax-key`, theme.SyntaxKey)
+	declare(`syntax-string`, theme.SyntaxString)
+	declare(`syntax-number`, theme.SyntaxNumber)
+	declare(`syntax-keyword`, theme.SyntaxKeyword)
+
+	for level := 1; level <= gradientLevels; level++ {
+		background := `inherit`
+		if len(theme.Gradient) > 0 {
+			background = theme.Gradient[min(level, len(theme.Gradient))-1]
+		}
+
+		declare(`level-`+strconv.Itoa(level), background)
+	}
+
+	return css.String()
+}
@@ -0,0 +1,56 @@
+package htmldump_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/oslyak/htmldump"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThemes(t *testing.T) {
+	t.Parallel()
+
+	filter := newFilterAccounts()
+
+	buffer := bytes.NewBuffer([]byte{})
+	err := htmldump.ToHTML(buffer, filter)
+	require.NoError(t, err)
+
+	require.Contains(t, buffer.String(), `--header-background: #009879;`)
+	require.Contains(t, buffer.String(), `@media (prefers-color-scheme: dark)`)
+	require.Contains(t, buffer.String(), `--header-background: `+htmldump.DarkTheme.HeaderBackground+`;`)
+	require.Contains(t, buffer.String(), `@media (prefers-contrast: more) and (prefers-color-scheme: light)`)
+	require.Contains(t, buffer.String(), `@media (prefers-contrast: more) and (prefers-color-scheme: dark) {
+      :root {
+          --page-background: #000000;`)
+	require.Contains(t, buffer.String(), `<td style="padding-left: 12px;background: var(--level-1);" >IncludeRemoved</td>`)
+
+	theme := htmldump.LightTheme
+	theme.HeaderBackground = `#123456`
+	theme.Gradient = []string{`#abcdef`}
+
+	buffer = bytes.NewBuffer([]byte{})
+	err = htmldump.NewDumper(htmldump.WithTheme(theme)).Dump(buffer, filter)
+	require.NoError(t, err)
+
+	require.Contains(t, buffer.String(), `--header-background: #123456;`)
+	require.Contains(t, buffer.String(), `--level-2: #abcdef;`)
+	require.NotContains(t, buffer.String(), `prefers-color-scheme`)
+
+	buffer = bytes.NewBuffer([]byte{})
+	err = htmldump.NewDumper(htmldump.WithColorSchemes(theme, htmldump.DarkTheme)).Dump(buffer, filter)
+	require.NoError(t, err)
+
+	require.Contains(t, buffer.String(), `@media (prefers-color-scheme: dark)`)
+	require.NotContains(t, buffer.String(), `prefers-contrast`)
+
+	theme.HeaderText = `red;}</style><script>alert(1)</script>{`
+
+	buffer = bytes.NewBuffer([]byte{})
+	err = htmldump.NewDumper(htmldump.WithTheme(theme)).Dump(buffer, filter)
+	require.NoError(t, err)
+
+	require.Contains(t, buffer.String(), `--header-text: red/stylescriptalert(1)/script;`)
+	require.NotContains(t, buffer.String(), `<script>alert`)
+}
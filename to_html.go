@@ -1,79 +1,40 @@
 package htmldump
 
 import (
-	"database/sql"
+	"encoding"
 	"errors"
 	"fmt"
 	"io"
-	"os"
 	"os/exec"
-	"path/filepath"
 	"reflect"
+	"strings"
 	"time"
 	"unsafe"
 )
 
 const NULL = `NULL`
 
-// ToHTML dumps the specified inputs to an HTML file at the specified path.
-// The inputs can be structs, slices, maps, or pointers to them.
+// ABSENT marks a key missing from a map element of a slice, while NULL marks a nil value.
+const ABSENT = `—`
+
+// ToHTML dumps the specified inputs to the writer as an HTML document.
+// The inputs can be values of any kind, or pointers to them.
+// It is a shortcut for Dump of a Dumper with the default options.
 func ToHTML(writer io.Writer, inputs ...interface{}) error {
 	if len(inputs) == 0 {
 		return errors.New(`[ToHTML] requires at least one inputs argument`)
 	}
 
-	var (
-		err error
-		doc = newHTMLDocument(writer)
-	)
-
-	for _, input := range inputs {
-		reflectedValue := reflect.ValueOf(input)
-
-		switch {
-		case isMapOrPointerToMap(reflectedValue):
-			err = mapToHTML(doc, reflectedValue)
-		case isPointerToSliceOrSlice(reflectedValue):
-			err = sliceToHTML(doc, reflectedValue)
-		case isStructOrPointerToStruct(reflectedValue.Type()):
-			err = structToHTML(doc, input)
-		case isStringOrPointerToString(reflectedValue.Type()):
-			err = stringToHTML(doc, reflectedValue)
-		default:
-			err = errors.New(`[ToHTML] only accepts: structs, slices, maps, strings, and pointers to them`)
-		}
-
-		if err != nil {
-			return err
-		}
-	}
-
-	doc.add("</body>\n</html>")
-	_, err = doc.save()
-
-	return err
+	return defaultDumper().Dump(writer, inputs...)
 }
 
-// ToHTMLAndOpen is a convenience function that calls ToHTML and then opens the
-// generated HTML file in the default browser.
+// ToHTMLAndOpen is a convenience function that dumps the inputs to an HTML file
+// at the specified path with the default options and then opens the file
+// in the default browser.
 func ToHTMLAndOpen(path string, inputs ...interface{}) {
-	absolutePath, err := filepath.Abs(path)
-	if err != nil {
-		werr := fmt.Errorf("[ToHTMLAndOpen] getting absolute path to %s error: %w", path, err)
-		panic(werr)
-	}
-
-	file, err := os.Create(absolutePath)
+	err := defaultDumper().DumpFile(path, inputs...)
 	if err != nil {
-		werr := fmt.Errorf("[ToHTMLAndOpen] file %s creating error: %w", absolutePath, err)
-		panic(werr)
-	}
-
-	defer file.Close()
-
-	err = ToHTML(file, inputs...)
-	if err != nil {
-		panic(err)
+		panic(fmt.Errorf("[ToHTMLAndOpen] %w", err))
 	}
 
 	openHTML(path)
@@ -92,6 +53,24 @@ func getUnexportedField(field reflect.Value) reflect.Value {
 	return reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem()
 }
 
+// isExpandable reports whether values of the type are shown as nested structs,
+// field by field, instead of a single formatted value.
+func (dumper *Dumper) isExpandable(valueType reflect.Type) bool {
+	return dumper.typePlan(valueType).expandable
+}
+
+// readableField returns the struct field, readable even when it is unexported.
+// Non-addressable structs, such as map values, are copied first.
+func readableField(structValue reflect.Value, idx int) reflect.Value {
+	if !structValue.CanAddr() {
+		addressable := reflect.New(structValue.Type()).Elem()
+		addressable.Set(structValue)
+		structValue = addressable
+	}
+
+	return getUnexportedField(structValue.Field(idx))
+}
+
 func convertToPointer(input interface{}) interface{} {
 	structType := reflect.TypeOf(input)
 
@@ -110,13 +89,27 @@ func convertToPointer(input interface{}) interface{} {
 
 func getFieldTypeName(fieldType reflect.Type) string {
 	if fieldType.Kind() == reflect.Pointer {
-		return `*` + fieldType.Elem().Name()
-	} else {
-		return fieldType.Name()
+		return `*` + getFieldTypeName(fieldType.Elem())
+	}
+
+	// Unnamed types, such as anonymous structs or slices, have no name to show.
+	if len(fieldType.Name()) == 0 {
+		return fieldType.String()
 	}
+
+	return fieldType.Name()
+}
+
+func (dumper *Dumper) formatValue(value reflect.Value, format ...string) string {
+	return dumper.formatValueLayout(value, dumper.timeFormat, format...)
 }
 
-func formatValue(value reflect.Value, format ...string) string {
+// formatValueLayout formats the value, time values with the layout.
+func (dumper *Dumper) formatValueLayout(value reflect.Value, layout string, format ...string) string {
+	if formatted, ok := dumper.customFormat(value); ok {
+		return formatted
+	}
+
 	valueType := value.Type()
 	if valueType.Kind() == reflect.Pointer {
 		valueType = valueType.Elem()
@@ -127,26 +120,159 @@ func formatValue(value reflect.Value, format ...string) string {
 		}
 	}
 
-	switch valueType.Name() {
-	case `Time`:
+	switch valueType {
+	case timeType:
 		t, ok := value.Interface().(time.Time)
 		if ok && !t.IsZero() {
-			return t.Format(`02.01.2006 15:04:05`)
-		}
-	case `NullTime`:
-		nullTime, ok := value.Interface().(sql.NullTime)
-		if !ok || !nullTime.Valid {
-			return NULL
-		} else {
-			return nullTime.Time.Format(`02.01.2006 15:04:05`)
+			return t.Format(layout)
 		}
 	default:
+		if isValuer(valueType) {
+			stored, ok, err := driverValue(value)
+
+			switch {
+			case err != nil:
+				return `error: ` + err.Error()
+			case ok && !stored.IsValid():
+				return NULL
+			case ok:
+				return dumper.formatValueLayout(stored, layout, format...)
+			}
+		}
+
+		if len(format) == 0 && valueType.Kind() == reflect.Struct {
+			if text, ok := marshalText(value); ok {
+				return text
+			}
+		}
+
 		return fmt.Sprintf(formatString(format...), value)
 	}
 
 	return ``
 }
 
+// marshalText formats a struct implementing encoding.TextMarshaler but none of
+// the interfaces fmt already calls.
+func marshalText(value reflect.Value) (string, bool) {
+	if !value.CanInterface() {
+		return ``, false
+	}
+
+	switch value.Interface().(type) {
+	case fmt.Stringer, error:
+		return ``, false
+	}
+
+	marshaler, ok := value.Interface().(encoding.TextMarshaler)
+	if !ok && value.CanAddr() {
+		marshaler, ok = value.Addr().Interface().(encoding.TextMarshaler)
+	}
+
+	if !ok {
+		return ``, false
+	}
+
+	text, err := marshaler.MarshalText()
+	if err != nil {
+		return ``, false
+	}
+
+	return string(text), true
+}
+
+// valueCell formats the value into a table cell. Only values of the HTML type
+// are marked as trusted markup, everything else is escaped on output.
+func (dumper *Dumper) valueCell(value reflect.Value, format ...string) cellT {
+	if len(format) == 0 && dumper.isBytes(value.Type()) {
+		return dumper.bytesCell(value)
+	}
+
+	return cellT{value: dumper.formatValue(value, format...), html: isTrustedHTML(value)}
+}
+
+// formatStruct formats the struct like %+v, but honours the field tags, so
+// hidden fields stay hidden in collapsed structs.
+func (dumper *Dumper) formatStruct(value reflect.Value) string {
+	return dumper.formatStructPath(value, make(map[uintptr]bool))
+}
+
+// formatStructPath formats the struct, path holds the pointers followed so far
+// and stops cycles.
+func (dumper *Dumper) formatStructPath(value reflect.Value, path map[uintptr]bool) string {
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return NULL
+		}
+
+		if path[value.Pointer()] {
+			return `↺`
+		}
+
+		path[value.Pointer()] = true
+		defer delete(path, value.Pointer())
+
+		value = value.Elem()
+	}
+
+	var result strings.Builder
+
+	result.WriteString(`{`)
+
+	for idx := 0; idx < value.NumField(); idx++ {
+		structField := value.Type().Field(idx)
+		tag := parseFieldTag(structField)
+
+		if tag.skip {
+			continue
+		}
+
+		if result.Len() > 1 {
+			result.WriteString(` `)
+		}
+
+		field := readableField(value, idx)
+
+		result.WriteString(tag.label(structField))
+		result.WriteString(`:`)
+
+		if dumper.isExpandable(field.Type()) && len(tag.format) == 0 {
+			result.WriteString(dumper.formatStructPath(field, path))
+		} else {
+			result.WriteString(dumper.fieldCell(field, tag).value)
+		}
+	}
+
+	result.WriteString(`}`)
+
+	return result.String()
+}
+
+// fieldCell formats a struct field value into a table cell honouring its tag.
+func (dumper *Dumper) fieldCell(value reflect.Value, tag fieldTagT) cellT {
+	layout := dumper.timeFormat
+	if len(tag.layout) > 0 {
+		layout = tag.layout
+	}
+
+	if tag.collapse && value.Kind() == reflect.Pointer && value.IsNil() {
+		return cellT{value: NULL}
+	}
+
+	if tag.collapse && len(tag.format) == 0 && isStructOrPointerToStruct(value.Type()) {
+		return cellT{value: dumper.formatStruct(value)}
+	}
+
+	if len(tag.format) == 0 && dumper.isBytes(value.Type()) {
+		return dumper.bytesCell(value)
+	}
+
+	return cellT{
+		value: dumper.formatValueLayout(value, layout, tag.formats()...),
+		html:  isTrustedHTML(value),
+	}
+}
+
 func formatString(format ...string) string {
 	if len(format) > 0 {
 		return format[0]
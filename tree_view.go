@@ -0,0 +1,112 @@
+package htmldump
+
+// treeViewStyle is added to the stylesheet of documents with collapsible struct rows.
+const treeViewStyle = `    .styled-table tr[data-branch] > td:first-child {
+        cursor: pointer;
+        user-select: none;
+    }
+    .styled-table tr[data-branch] > td:first-child::before {
+        content: "▾ ";
+    }
+    .styled-table tr[data-branch][data-collapsed] > td:first-child::before {
+        content: "▸ ";
+    }
+    div.tree-controls {
+        margin: 25px 0 -15px 0;
+        font-size: 0.9em;
+    }
+    div.tree-controls button {
+        margin-right: 4px;
+    }
+`
+
+// treeViewScript makes the struct-valued rows of struct tables collapsible.
+// Rows hold their nesting level in data-level, zero when missing, and the
+// rows followed by the fields of their struct are marked with data-branch.
+// A row is hidden while any branch above it with a lower level is collapsed.
+// Top level tables get buttons expanding all rows or collapsing them to a level.
+const treeViewScript = `  <script>
+    document.addEventListener('DOMContentLoaded', function () {
+      function level(row) {
+        return Number(row.dataset.level || 0);
+      }
+
+      function refresh(table) {
+        let hiddenBelow = Infinity;
+        for (const row of table.tBodies[0].rows) {
+          if (level(row) <= hiddenBelow) {
+            hiddenBelow = Infinity;
+          }
+          row.hidden = level(row) > hiddenBelow;
+          if (!row.hidden && row.dataset.collapsed !== undefined) {
+            hiddenBelow = level(row);
+          }
+        }
+      }
+
+      function collapseTo(table, depth) {
+        for (const row of table.tBodies[0].rows) {
+          if (row.dataset.branch === undefined) {
+            continue;
+          }
+          if (level(row) >= depth - 1) {
+            row.dataset.collapsed = '';
+          } else {
+            delete row.dataset.collapsed;
+          }
+        }
+        refresh(table);
+      }
+
+      for (const table of document.querySelectorAll('table.styled-table')) {
+        const branches = Array.from(table.tBodies[0].rows).filter(function (row) {
+          return row.dataset.branch !== undefined;
+        });
+        if (branches.length === 0) {
+          continue;
+        }
+
+        for (const row of branches) {
+          row.cells[0].addEventListener('click', function () {
+            if (row.dataset.collapsed === undefined) {
+              row.dataset.collapsed = '';
+            } else {
+              delete row.dataset.collapsed;
+            }
+            refresh(table);
+          });
+        }
+
+        if (table.classList.contains('nested')) {
+          continue;
+        }
+
+        const controls = document.createElement('div');
+        controls.className = 'tree-controls';
+
+        const expand = document.createElement('button');
+        expand.textContent = 'Expand all';
+        expand.addEventListener('click', function () { collapseTo(table, Infinity); });
+        controls.appendChild(expand);
+
+        const deepest = Math.max.apply(null, branches.map(level));
+        for (let depth = 1; depth <= deepest + 1; depth++) {
+          const collapse = document.createElement('button');
+          collapse.textContent = 'Collapse to level ' + depth;
+          collapse.addEventListener('click', function () { collapseTo(table, depth); });
+          controls.appendChild(collapse);
+        }
+
+        table.parentNode.insertBefore(controls, table);
+      }
+    });
+  </script>`
+
+// WithTreeView embeds a script making the struct-valued rows of struct tables
+// collapsible: clicking the field name hides or shows the fields of its struct,
+// and buttons above each table expand all rows or collapse them to a level.
+func WithTreeView() Option {
+	return func(dumper *Dumper) {
+		dumper.treeView = true
+	}
+}
@@ -0,0 +1,33 @@
+package htmldump_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/oslyak/htmldump"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTreeView(t *testing.T) {
+	t.Parallel()
+
+	filter := newFilterAccounts()
+
+	buffer := bytes.NewBuffer([]byte{})
+	err := htmldump.NewDumper(htmldump.WithTreeView()).Dump(buffer, filter)
+	require.NoError(t, err)
+
+	table := removeStyle(t, extractHTMLTable(t, buffer.String()))
+	require.Contains(t, table, `<tr data-branch><td>filter</td><td>filter</td><td></td></tr>`+
+		`<tr data-level="1"><td>IncludeRemoved</td><td>bool</td>`)
+	require.Contains(t, table, `<tr  data-level="1" data-branch><td>orderPtr</td><td>*order</td><td></td></tr>`+
+		`<tr data-level="2"><td>Column</td><td>string</td><td>pointer</td></tr>`)
+	require.Contains(t, buffer.String(), `tree-controls`)
+
+	buffer = bytes.NewBuffer([]byte{})
+	err = htmldump.ToHTML(buffer, filter)
+	require.NoError(t, err)
+
+	require.NotContains(t, buffer.String(), `data-level`)
+	require.NotContains(t, buffer.String(), `tree-controls`)
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
 )
 
@@ -25,7 +26,7 @@ func MapTypeName(mapType reflect.Type) (string, error) {
 	return result, nil
 }
 
-// MapTypeName returns the name of map key, value types.
+// SliceTypeName returns the name of a slice or array type, with its element type.
 func SliceTypeName(sliceType reflect.Type) (string, error) {
 	var result strings.Builder
 
@@ -35,11 +36,15 @@ func SliceTypeName(sliceType reflect.Type) (string, error) {
 		result.WriteString(`*`)
 	}
 
-	if sliceType.Kind() != reflect.Slice {
-		return ``, errors.New(`[SliceTypeName] the input parameter is not a slice or pointer to slice`)
+	switch sliceType.Kind() {
+	case reflect.Slice:
+		result.WriteString(`[]`)
+	case reflect.Array:
+		result.WriteString(`[` + strconv.Itoa(sliceType.Len()) + `]`)
+	default:
+		return ``, errors.New(`[SliceTypeName] the input parameter is not a slice, an array or pointer to them`)
 	}
 
-	result.WriteString(`[]`)
 	result.WriteString(sliceType.Elem().String())
 
 	return result.String(), nil
@@ -248,6 +248,16 @@ func TestSliceTypeName(t *testing.T) {
 			input:    []*[]*[]*[]int{},
 			expected: `[]*[]*[]*[]int`,
 		},
+		{
+			name:     `[3]int`,
+			input:    [3]int{},
+			expected: `[3]int`,
+		},
+		{
+			name:     `*[2][]string`,
+			input:    &[2][]string{},
+			expected: `*[2][]string`,
+		},
 	}
 
 	for _, tc := range testCases {